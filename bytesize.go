@@ -0,0 +1,209 @@
+package cast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// byteUnits maps the units accepted by ToByteSizeE, in lower case, to their
+// number of bytes.
+var byteUnits = map[string]*big.Int{}
+
+func init() {
+	for i, prefix := range []string{"k", "m", "g", "t", "p", "e", "z", "y"} {
+		si := new(big.Int).Exp(big.NewInt(1000), big.NewInt(int64(i+1)), nil)
+		iec := new(big.Int).Lsh(big.NewInt(1), uint(10*(i+1)))
+		byteUnits[prefix], byteUnits[prefix+"b"] = si, si
+		byteUnits[prefix+"i"], byteUnits[prefix+"ib"] = iec, iec
+	}
+	for _, unit := range []string{"b", "byte", "bytes"} {
+		byteUnits[unit] = big.NewInt(1)
+	}
+}
+
+// ToByteSize casts an interface to a number of bytes.
+func ToByteSize(a any) uint64 {
+	v, _ := ToByteSizeE(a)
+	return v
+}
+
+// ToByteSizeE casts an interface to a number of bytes.
+//
+// Strings are numbers followed by an optional unit, such as "512MiB", "1.5GB"
+// or "10 k". The units are matched case-insensitively: "B", "byte" and
+// "bytes"; the SI units "k" or "kB", "M" or "MB", and so on up to "Y" or "YB",
+// powers of 1000; and the IEC units "Ki" or "KiB", "Mi" or "MiB", and so on up
+// to "Yi" or "YiB", powers of 1024. The number is parsed like ToBigRatE and a
+// fraction of a byte is truncated, or rounded with the RoundingMode of a
+// Caster. Other values are cast like ToUint64E. Negative sizes fail with
+// ErrNegative, and sizes beyond uint64 with ErrOverflow.
+func ToByteSizeE(a any) (uint64, error) {
+	return std.ToByteSizeE(a)
+}
+
+// ToByteSizeE casts an interface to a number of bytes with the settings of c.
+func (c *Caster) ToByteSizeE(a any) (uint64, error) {
+	n, err := c.ToBigByteSizeE(a)
+	if err != nil {
+		return 0, wrapError(a, "uint64", err)
+	}
+	if !n.IsUint64() {
+		return 0, overflowError(a, "uint64")
+	}
+	return n.Uint64(), nil
+}
+
+// ToBigByteSize casts an interface to a number of bytes of a *big.Int type.
+func ToBigByteSize(a any) *big.Int {
+	v, _ := ToBigByteSizeE(a)
+	return v
+}
+
+// ToBigByteSizeE casts an interface to a number of bytes of a *big.Int type,
+// like ToByteSizeE but without an upper bound.
+func ToBigByteSizeE(a any) (*big.Int, error) {
+	return std.ToBigByteSizeE(a)
+}
+
+// ToBigByteSizeE casts an interface to a number of bytes of a *big.Int type
+// with the settings of c.
+func (c *Caster) ToBigByteSizeE(a any) (*big.Int, error) {
+	n, err := castTo[byteSize](c, a)
+	if err != nil {
+		return big.NewInt(0), wrapError(a, "*big.Int", err)
+	}
+	return n.n, nil
+}
+
+// byteSize is the type of the casts to a number of bytes, for which the
+// casters map holds toByteSizeE.
+type byteSize struct {
+	n *big.Int
+}
+
+func (c *Caster) toByteSizeE(a any) (byteSize, error) {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toByteSizeE(b)
+		return n, wrapError(a, "*big.Int", err)
+	}
+
+	var (
+		n   *big.Int
+		err error
+	)
+	switch v := a.(type) {
+	case string:
+		n, err = c.parseByteSize(v, a)
+	case []byte:
+		n, err = c.parseByteSize(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return byteSize{big.NewInt(0)}, newCastError(a, "*big.Int", ErrSyntax, nil)
+		}
+		n, err = c.parseByteSize(string(v), a)
+	case fmt.Stringer:
+		n, err = c.parseByteSize(v.String(), a)
+	case error:
+		n, err = c.parseByteSize(v.Error(), a)
+	default:
+		var r *big.Rat
+		if r, err = c.toBigRatE(a); err == nil {
+			n, err = c.byteCount(r, a)
+		}
+		err = wrapError(a, "*big.Int", err)
+	}
+	if err != nil {
+		return byteSize{big.NewInt(0)}, err
+	}
+	if n.Sign() < 0 {
+		return byteSize{big.NewInt(0)}, newCastError(a, "*big.Int", ErrNegative, nil)
+	}
+	return byteSize{n}, nil
+}
+
+// parseByteSize parses s, the text of a, as described by ToByteSizeE.
+func (c *Caster) parseByteSize(s string, a any) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	number, unit := s, big.NewInt(1)
+	if c.dec.lex(s) == tokenInvalid {
+		i := strings.LastIndexFunc(s, func(r rune) bool {
+			r |= 0x20
+			return r < 'a' || r > 'z'
+		})
+		if u, ok := byteUnits[strings.ToLower(s[i+1:])]; ok {
+			number, unit = strings.TrimSpace(s[:i+1]), u
+		}
+	}
+
+	r, err := c.withoutProportions().dec.ToBigRat(number)
+	if err != nil {
+		return nil, newCastError(a, "*big.Int", errorKind(err), nil)
+	}
+	return c.byteCount(r.Mul(r, new(big.Rat).SetInt(unit)), a)
+}
+
+// byteCount returns the number of bytes r, truncated or rounded with the
+// RoundingMode of c. A fraction of a byte fails with ErrInexact in Strict
+// mode. It does not go through castTo, which would make the casters map
+// depend on itself.
+func (c *Caster) byteCount(r *big.Rat, a any) (*big.Int, error) {
+	switch {
+	case r.IsInt():
+		return new(big.Int).Set(r.Num()), nil
+	case c.strict:
+		return nil, newCastError(a, "*big.Int", ErrInexact, nil)
+	case c.rounding != RoundTruncate:
+		return roundRat(r, c.rounding), nil
+	default:
+		return ratToBigInt(r), nil
+	}
+}
+
+// ByteUnits selects the units of FormatByteSize.
+type ByteUnits int
+
+const (
+	// SIUnits are the powers of 1000: "kB", "MB", "GB" and so on.
+	SIUnits ByteUnits = iota
+	// IECUnits are the powers of 1024: "KiB", "MiB", "GiB" and so on.
+	IECUnits
+)
+
+var byteUnitNames = map[ByteUnits][]string{
+	SIUnits:  {"B", "kB", "MB", "GB", "TB", "PB", "EB"},
+	IECUnits: {"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"},
+}
+
+// FormatByteSize writes the number of bytes n with the largest of units in
+// which it is at least 1, rounded half away from zero to two decimals, such as
+// "512MiB" or "1.5GB". The rounding loses the bytes below its precision, so
+// ToByteSizeE casts the result back to n only when none were lost.
+func FormatByteSize(n uint64, units ByteUnits) string {
+	names, ok := byteUnitNames[units]
+	if !ok {
+		names = byteUnitNames[SIUnits]
+	}
+	base := decimal.NewFromInt(1000)
+	if units == IECUnits {
+		base = decimal.NewFromInt(1024)
+	}
+
+	size := decimal.NewFromBigInt(new(big.Int).SetUint64(n), 0)
+	i := 0
+	for i+1 < len(names) && size.GreaterThanOrEqual(base) {
+		size = size.Div(base)
+		i++
+	}
+	size = size.Round(2)
+	if i+1 < len(names) && size.GreaterThanOrEqual(base) {
+		// Rounding carried into the next unit.
+		size = size.Div(base).Round(2)
+		i++
+	}
+	return size.String() + names[i]
+}
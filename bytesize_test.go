@@ -0,0 +1,161 @@
+package cast_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+func TestToByteSizeE(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		input  any
+		expect uint64
+		kind   cast.ErrorKind
+	}{
+		{"512MiB", 512 << 20, 0},
+		{"1.5GB", 1500000000, 0},
+		{"10k", 10000, 0},
+		{"10 K", 10000, 0},
+		{" 10 kb ", 10000, 0},
+		{"1.5 KiB", 1536, 0},
+		{"2gib", 2 << 30, 0},
+		{"3Ti", 3 << 40, 0},
+		{"1 byte", 1, 0},
+		{"100 bytes", 100, 0},
+		{"100B", 100, 0},
+		{"4096", 4096, 0},
+		{"1e3", 1000, 0},
+		{"0x1b", 27, 0},
+		{"1/2 KiB", 512, 0},
+		{"1.0001kB", 1000, 0},
+		{"16EiB", 0, cast.ErrOverflow},
+		{"15EiB", 15 << 60, 0},
+		{"18446744073709551615", math.MaxUint64, 0},
+		{"18446744073709551616", 0, cast.ErrOverflow},
+		{"-1KB", 0, cast.ErrNegative},
+		{"1.5", 1, 0},
+		{"GB", 0, cast.ErrSyntax},
+		{"10 parsecs", 0, cast.ErrSyntax},
+		{"", 0, cast.ErrSyntax},
+		{[]byte("1MB"), 1000000, 0},
+		{4096, 4096, 0},
+		{2.5, 2, 0},
+		{-1, 0, cast.ErrNegative},
+		{big.NewInt(1 << 40), 1 << 40, 0},
+		{nil, 0, 0},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := cast.ToByteSizeE(test.input)
+		if test.kind != 0 {
+			c.Assert(errors.Is(err, test.kind), IsTrue, Commentf("i = %d, input = %#v, err = %v", i, test.input, err))
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, Equals, test.expect, errmsg)
+		c.Assert(cast.ToByteSize(test.input), Equals, test.expect, errmsg)
+	}
+
+	_, err := cast.ToByteSizeE("10 parsecs")
+	c.Assert(err, ErrorMatches, `unable to cast "10 parsecs" of type string to uint64: invalid syntax`)
+
+	n, err := cast.ToBigByteSizeE("2YiB")
+	c.Assert(err, IsNil)
+	c.Assert(n.String(), Equals, new(big.Int).Lsh(big.NewInt(2), 80).String())
+	n, err = cast.ToBigByteSizeE("1.5ZB")
+	c.Assert(err, IsNil)
+	c.Assert(n.String(), Equals, "1500000000000000000000")
+	_, err = cast.ToBigByteSizeE("-1B")
+	c.Assert(errors.Is(err, cast.ErrNegative), IsTrue)
+
+	strict, err := cast.New(cast.Strict)
+	c.Assert(err, IsNil)
+	_, err = strict.ToByteSizeE("1.0001kB")
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+	ceil, err := cast.New(cast.RoundCeil)
+	c.Assert(err, IsNil)
+	v, err := ceil.ToByteSizeE("1.0001kB")
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, uint64(1001))
+	_, err = strict.ToByteSizeE(1.5)
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+	v, err = ceil.ToByteSizeE(1.5)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, uint64(2))
+	_, err = cast.ToByteSizeE("20EiB")
+	c.Assert(err, ErrorMatches, `unable to cast "20EiB" of type string to uint64: .*`)
+	de, _ := cast.LookupLocale("de-DE")
+	german, err := cast.New(de)
+	c.Assert(err, IsNil)
+	v, err = german.ToByteSizeE("1,5 GB")
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, uint64(1500000000))
+
+	// The standard conversion interfaces and NilMode apply as for the other
+	// casts.
+	v, err = cast.ToByteSizeE(textOnly{"1.5kB"})
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, uint64(1500))
+	v, err = cast.ToByteSizeE(&sql.NullInt64{Int64: 512, Valid: true})
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, uint64(512))
+	v, err = cast.ToByteSizeE(json.Number("1e3"))
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, uint64(1000))
+	_, err = cast.ToByteSizeE(json.Number("1kB"))
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+	v, err = cast.ToByteSizeE((*string)(nil))
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, uint64(0))
+	nilError, err := cast.New(cast.NilError)
+	c.Assert(err, IsNil)
+	_, err = nilError.ToBigByteSizeE((*string)(nil))
+	c.Assert(errors.Is(err, cast.ErrNil), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast \(\*string\)\(nil\) of type \*string to \*big.Int: .*`)
+}
+
+func TestFormatByteSize(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		input  uint64
+		units  cast.ByteUnits
+		expect string
+	}{
+		{0, cast.SIUnits, "0B"},
+		{999, cast.SIUnits, "999B"},
+		{1000, cast.SIUnits, "1kB"},
+		{1500000000, cast.SIUnits, "1.5GB"},
+		{1234567, cast.SIUnits, "1.23MB"},
+		{999999, cast.SIUnits, "1MB"},
+		{math.MaxUint64, cast.SIUnits, "18.45EB"},
+		{1023, cast.IECUnits, "1023B"},
+		{1024, cast.IECUnits, "1KiB"},
+		{1536, cast.IECUnits, "1.5KiB"},
+		{512 << 20, cast.IECUnits, "512MiB"},
+		{1<<20 - 1, cast.IECUnits, "1MiB"},
+		{math.MaxUint64, cast.IECUnits, "16EiB"},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %d", i, test.input)
+
+		s := cast.FormatByteSize(test.input, test.units)
+		c.Assert(s, Equals, test.expect, errmsg)
+		if test.input%1024 == 0 {
+			n, err := cast.ToByteSizeE(s)
+			c.Assert(err, IsNil, errmsg)
+			c.Assert(n, Equals, test.input, errmsg)
+		}
+	}
+}
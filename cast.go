@@ -1,11 +1,15 @@
 package cast
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
 type stringer struct{ string }
@@ -42,6 +46,10 @@ func indirectToStringerOrError(a any) any {
 	if a == nil {
 		return nil
 	}
+	if t := reflect.TypeOf(a); t.Kind() != reflect.Ptr {
+		// Avoid the Implements checks if it's not a pointer.
+		return a
+	}
 
 	var errorType = reflect.TypeOf((*error)(nil)).Elem()
 	var fmtStringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
@@ -53,364 +61,480 @@ func indirectToStringerOrError(a any) any {
 	return v.Interface()
 }
 
-type decimalParser struct{}
+var builtinTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       reflect.TypeOf(false),
+	reflect.Int:        reflect.TypeOf(int(0)),
+	reflect.Int8:       reflect.TypeOf(int8(0)),
+	reflect.Int16:      reflect.TypeOf(int16(0)),
+	reflect.Int32:      reflect.TypeOf(int32(0)),
+	reflect.Int64:      reflect.TypeOf(int64(0)),
+	reflect.Uint:       reflect.TypeOf(uint(0)),
+	reflect.Uint8:      reflect.TypeOf(uint8(0)),
+	reflect.Uint16:     reflect.TypeOf(uint16(0)),
+	reflect.Uint32:     reflect.TypeOf(uint32(0)),
+	reflect.Uint64:     reflect.TypeOf(uint64(0)),
+	reflect.Float32:    reflect.TypeOf(float32(0)),
+	reflect.Float64:    reflect.TypeOf(float64(0)),
+	reflect.Complex64:  reflect.TypeOf(complex64(0)),
+	reflect.Complex128: reflect.TypeOf(complex128(0)),
+	reflect.String:     reflect.TypeOf(""),
+}
+
+// indirectToBuiltin returns the value converted to the predeclared type of its
+// kind when it is of a user-defined type such as time.Duration or
+// `type UserID string`, other than json.Number. A slice of a byte kind is
+// returned as a []byte. The second result reports whether a conversion took
+// place.
+func indirectToBuiltin(a any) (any, bool) {
+	switch a.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, complex64, complex128, string, []byte:
+		return a, false
+	case json.Number:
+		// The casts reading it as a number check its syntax, the others use
+		// its String.
+		return a, false
+	}
+	v := reflect.ValueOf(a)
+	t := v.Type()
+	if b, ok := builtinTypes[t.Kind()]; ok {
+		if t == b {
+			return a, false
+		}
+		return v.Convert(b).Interface(), true
+	}
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && t != bytesType {
+		return v.Bytes(), true
+	}
+	return a, false
+}
 
-var dec = decimalParser{}
+var bytesType = reflect.TypeOf([]byte(nil))
 
-func (p decimalParser) ToInt(s string) (int64, error) {
+// decimalParser converts the strings of the grammar documented in lexer.go.
+type decimalParser struct {
+	// words maps the words accepted as bools, in lower case, to their values.
+	// The bools of the grammar are accepted when it is nil.
+	words map[string]bool
+	// rejected holds the letters of the base prefixes that are not accepted.
+	rejected string
+	// locale, when not nil, is the Locale of the numbers also accepted.
+	locale *Locale
+	// proportions reports whether the casts to floats and rationals accept
+	// proportions.
+	proportions bool
+}
+
+// number is the value of a string of the grammar. A finite real part is held
+// exactly in rat, or in float when its exponent is beyond what big.Rat parses.
+// An infinite real part is held in float and a NaN one is flagged by nan.
+type number struct {
+	rat   *big.Rat
+	float *big.Float
+	nan   bool
+	imag  float64
+}
+
+func realNumber(f float64) number {
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return 0, err
-		}
-		if b {
-			return 1, nil
-		}
-		return 0, nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return 0, err
-		}
-		return int64(real(n)), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		f, _ := n.Float64()
-		return int64(f), nil
+	case math.IsNaN(f):
+		return number{nan: true}
+	case math.IsInf(f, 0):
+		return number{float: big.NewFloat(f)}
 	default:
-		s = p.trimPointZeroOfIntString(s)
-		n, ok := big.NewInt(0).SetString(s, 0)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Int", s, s)
-		}
-		return n.Int64(), nil
+		return number{rat: new(big.Rat).SetFloat64(f)}
 	}
 }
 
-func (p decimalParser) ToUint(s string) (uint64, error) {
-	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return 0, err
-		}
-		if b {
-			return 1, nil
-		}
-		return 0, nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return 0, err
+// lex returns the class of s like the lex function, with the bool words,
+// base prefixes and locale accepted by p.
+func (p decimalParser) lex(s string) tokenKind {
+	s, ok := p.localized(s)
+	if !ok {
+		return tokenInvalid
+	}
+	return p.lexGrammar(s)
+}
+
+// lexGrammar returns the class of s, written in the grammar, with the bool
+// words and base prefixes accepted by p.
+func (p decimalParser) lexGrammar(s string) tokenKind {
+	if p.words != nil {
+		if _, ok := p.words[strings.ToLower(s)]; ok {
+			return tokenBool
 		}
-		return uint64(real(n)), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
+	}
+
+	k := lex(s)
+	switch {
+	case k == tokenBool && p.words != nil:
+		return tokenInvalid
+	case k != tokenBool && hasPrefix(s, p.rejected):
+		return tokenInvalid
+	case (k == tokenInt || k == tokenRat) && p.legacyOctal() && !hasOctalDigits(s):
+		return tokenInvalid
+	}
+	return k
+}
+
+// complexText returns s written in the grammar when it is of the complex
+// class, reporting whether it is.
+func (p decimalParser) complexText(s string) (string, bool) {
+	s, ok := p.localized(s)
+	return s, ok && p.lexGrammar(s) == tokenComplex
+}
+
+// bool returns the value of a string of the bool class.
+func (p decimalParser) bool(s string) bool {
+	if p.words != nil {
+		return p.words[strings.ToLower(s)]
+	}
+	b, _ := strconv.ParseBool(s)
+	return b
+}
+
+func (p decimalParser) parse(s string) (number, error) {
+	s, ok := p.localized(s)
+	if !ok {
+		return number{}, ErrSyntax
+	}
+
+	switch p.lexGrammar(s) {
+	case tokenBool:
+		if p.bool(s) {
+			return number{rat: big.NewRat(1, 1)}, nil
+		}
+		return number{rat: big.NewRat(0, 1)}, nil
+	case tokenInt:
+		return number{rat: new(big.Rat).SetInt(p.parseInteger(s))}, nil
+	case tokenFloat:
+		s = strings.ReplaceAll(trimFloatSuffix(s), "_", "")
+		if r, ok := new(big.Rat).SetString(s); ok {
+			return number{rat: r}, nil
+		}
+		f, ok := new(big.Float).SetString(s)
 		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
+			return number{}, ErrSyntax
+		}
+		return number{float: f}, nil
+	case tokenSpecial:
+		f, _ := strconv.ParseFloat(s, 64)
+		return realNumber(f), nil
+	case tokenRat:
+		i := strings.IndexByte(s, '/')
+		num, denom := p.parseInteger(s[:i]), p.parseInteger(s[i+1:])
+		if denom.Sign() == 0 {
+			return number{}, ErrNotFinite
+		}
+		return number{rat: new(big.Rat).SetFrac(num, denom)}, nil
+	case tokenComplex:
+		c, err := strconv.ParseComplex(s, 128)
+		if err != nil {
+			return number{}, err
 		}
-		f, _ := n.Float64()
-		return uint64(f), nil
+		n := realNumber(real(c))
+		n.imag = imag(c)
+		return n, nil
 	default:
-		s = p.trimPointZeroOfIntString(s)
-		n, ok := big.NewInt(0).SetString(s, 0)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Int", s, s)
-		}
-		if n.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", s, s)
+		return number{}, ErrSyntax
+	}
+}
+
+// parseInteger returns the value of a string of the integer class.
+func (p decimalParser) parseInteger(s string) *big.Int {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.ReplaceAll(strings.TrimLeft(s, "+-"), "_", "")
+	base := 10
+	if len(s) > 1 && s[0] == '0' {
+		switch s[1] | 0x20 {
+		case 'x':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
 		}
-		return n.Uint64(), nil
 	}
+	if base != 10 {
+		s = s[2:]
+	} else if p.legacyOctal() && isLegacyOctal(s) {
+		base = 8
+	}
+	n, _ := new(big.Int).SetString(s, base)
+	if neg {
+		n.Neg(n)
+	}
+	return n
 }
 
-func (p decimalParser) ToFloat32(s string) (float32, error) {
+// legacyOctal reports whether p reads integers with a leading zero as octal,
+// which it does unless base 8 is rejected.
+func (p decimalParser) legacyOctal() bool {
+	return strings.IndexByte(p.rejected, 'o') < 0
+}
+
+func (n number) isZero() bool {
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return 0, err
-		}
-		if b {
-			return 1, nil
-		}
-		return 0, nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return 0, err
-		}
-		return float32(real(n)), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		f, _ := n.Float32()
-		return f, nil
+	case n.nan:
+		return false
+	case n.rat != nil:
+		return n.rat.Sign() == 0 && n.imag == 0
 	default:
-		f, ok := big.NewFloat(0).SetString(s)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Float", s, s)
-		}
-		f32, _ := f.Float32()
-		return f32, nil
+		return n.float.Sign() == 0 && n.imag == 0
 	}
 }
 
-func (p decimalParser) ToFloat64(s string) (float64, error) {
+// sign returns the sign of the real part, and 0 for NaN.
+func (n number) sign() int {
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return 0, err
-		}
-		if b {
-			return 1, nil
-		}
-		return 0, nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return 0, err
-		}
-		return real(n), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		f, _ := n.Float64()
-		return f, nil
+	case n.nan:
+		return 0
+	case n.rat != nil:
+		return n.rat.Sign()
 	default:
-		f, ok := big.NewFloat(0).SetString(s)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Float", s, s)
-		}
-		f64, _ := f.Float64()
-		return f64, nil
+		return n.float.Sign()
 	}
 }
 
-func (p decimalParser) ToBigInt(s string) (*big.Int, error) {
+// bigInt truncates the real part toward zero.
+func (n number) bigInt() (*big.Int, error) {
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return nil, err
-		}
-		if b {
-			return big.NewInt(1), nil
-		}
-		return big.NewInt(0), nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return nil, err
-		}
-		return big.NewInt(int64(real(n))), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		f, _ := n.Float64()
-		return big.NewInt(int64(f)), nil
+	case n.rat != nil:
+		return ratToBigInt(n.rat), nil
+	case n.nan || n.float.IsInf():
+		return nil, ErrNotFinite
 	default:
-		s = p.trimPointZeroOfIntString(s)
-		n, ok := big.NewInt(0).SetString(s, 0)
-		if !ok {
-			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Int", s, s)
-		}
-		return n, nil
+		i, _ := n.float.Int(nil)
+		return i, nil
 	}
 }
 
-func (p decimalParser) ToBigFloat(s string) (*big.Float, error) {
+func (n number) bigFloat() (*big.Float, error) {
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return nil, err
-		}
-		if b {
-			return big.NewFloat(1), nil
-		}
-		return big.NewFloat(0), nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return nil, err
-		}
-		return big.NewFloat(real(n)), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		f, _ := n.Float64()
-		return big.NewFloat(f), nil
+	case n.rat != nil:
+		return new(big.Float).SetRat(n.rat), nil
+	case n.nan:
+		return nil, ErrNotFinite
 	default:
-		f, ok := big.NewFloat(0).SetString(s)
-		if !ok {
-			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", s, s)
-		}
-		return f, nil
+		return n.float, nil
 	}
 }
 
-func (p decimalParser) ToBigRat(s string) (*big.Rat, error) {
+func (n number) bigRat() (*big.Rat, error) {
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return nil, err
-		}
-		if b {
-			return big.NewRat(1, 1), nil
-		}
-		return big.NewRat(0, 1), nil
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return nil, err
-		}
-		if b {
-			return big.NewRat(1, 1), nil
-		}
-		return big.NewRat(0, 1), nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return nil, err
-		}
-		return big.NewRat(int64(real(n)), 1), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		return n, nil
+	case n.rat != nil:
+		return n.rat, nil
+	case n.nan || n.float.IsInf():
+		return nil, ErrNotFinite
 	default:
-		f, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		return f, nil
+		r, _ := n.float.Rat(nil)
+		return r, nil
 	}
 }
 
-func (p decimalParser) ToComplex64(s string) (complex64, error) {
+// float32 rounds the real part from its exact value, failing when a finite
+// value is too large in magnitude to be represented.
+func (n number) float32() (float32, error) {
+	var f float32
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return complex(0, 0), err
-		}
-		if b {
-			return complex(1, 0), nil
-		}
-		return complex(0, 0), nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 64)
-		if err != nil {
-			return 0, err
-		}
-		return complex64(n), nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		f, _ := n.Float32()
-		return complex(f, 0), nil
+	case n.nan:
+		return float32(math.NaN()), nil
+	case n.rat != nil:
+		f, _ = n.rat.Float32()
+	case n.float.IsInf():
+		f, _ = n.float.Float32()
+		return f, nil
 	default:
-		n, err := strconv.ParseComplex(s, 64)
-		if err != nil {
-			return 0, err
-		}
-		return complex64(n), nil
+		f, _ = n.float.Float32()
+	}
+	if math.IsInf(float64(f), 0) {
+		return 0, ErrOverflow
 	}
+	return f, nil
 }
 
-func (p decimalParser) ToComplex128(s string) (complex128, error) {
+// float64 rounds the real part from its exact value, failing when a finite
+// value is too large in magnitude to be represented.
+func (n number) float64() (float64, error) {
+	var f float64
 	switch {
-	case strings.ContainsAny(s, "tf"):
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return complex(0, 0), err
+	case n.nan:
+		return math.NaN(), nil
+	case n.rat != nil:
+		f, _ = n.rat.Float64()
+	case n.float.IsInf():
+		f, _ = n.float.Float64()
+		return f, nil
+	default:
+		f, _ = n.float.Float64()
+	}
+	if math.IsInf(f, 0) {
+		return 0, ErrOverflow
+	}
+	return f, nil
+}
+
+// numError returns the syntax and range errors of parsing s as the
+// *strconv.NumError that the strconv function fn returns for them, and other
+// errors unchanged.
+func numError(fn, s string, err error) error {
+	switch err {
+	case ErrSyntax:
+		return &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrSyntax}
+	case ErrOverflow:
+		return &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrRange}
+	}
+	return err
+}
+
+// isPlain reports whether s is a plain decimal number that strconv parses as
+// p does, which is not the case with a locale or when s is a bool word.
+func (p decimalParser) isPlain(s string) bool {
+	if _, ok := p.words[s]; ok || p.locale != nil {
+		return false
+	}
+	return isPlainDecimal(s)
+}
+
+func (p decimalParser) ToInt(s string) (int64, error) {
+	if p.isPlain(s) {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n, nil
 		}
-		if b {
-			return complex(1, 0), nil
+	}
+	n, err := p.ToBigInt(s)
+	if err != nil {
+		return 0, numError("ParseInt", s, err)
+	}
+	if !n.IsInt64() {
+		return 0, numError("ParseInt", s, ErrOverflow)
+	}
+	return n.Int64(), nil
+}
+
+func (p decimalParser) ToUint(s string) (uint64, error) {
+	if p.isPlain(s) {
+		if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return n, nil
 		}
-		return complex(0, 0), nil
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return 0, err
+	}
+	v, err := p.parse(s)
+	if err != nil {
+		return 0, numError("ParseUint", s, err)
+	}
+	n, err := v.bigInt()
+	if err != nil {
+		return 0, numError("ParseUint", s, err)
+	}
+	// A negative fraction is rejected even though it truncates to zero.
+	if v.sign() < 0 {
+		return 0, ErrNegative
+	}
+	if !n.IsUint64() {
+		return 0, numError("ParseUint", s, ErrOverflow)
+	}
+	return n.Uint64(), nil
+}
+
+func (p decimalParser) ToFloat32(s string) (float32, error) {
+	if p.isPlain(s) {
+		if f, err := strconv.ParseFloat(s, 32); err == nil {
+			return float32(f), nil
 		}
-		return n, nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
+	}
+	n, err := p.parseReal(s)
+	if err != nil {
+		return 0, numError("ParseFloat", s, err)
+	}
+	f, err := n.float32()
+	return f, numError("ParseFloat", s, err)
+}
+
+func (p decimalParser) ToFloat64(s string) (float64, error) {
+	if p.isPlain(s) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return float64(f), nil
 		}
-		f, _ := n.Float64()
-		return complex(f, 0), nil
-	default:
-		n, err := strconv.ParseComplex(s, 128)
+	}
+	n, err := p.parseReal(s)
+	if err != nil {
+		return 0, numError("ParseFloat", s, err)
+	}
+	f, err := n.float64()
+	return f, numError("ParseFloat", s, err)
+}
+
+func (p decimalParser) ToBigInt(s string) (*big.Int, error) {
+	n, err := p.parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return n.bigInt()
+}
+
+func (p decimalParser) ToBigFloat(s string) (*big.Float, error) {
+	n, err := p.parseReal(s)
+	if err != nil {
+		return nil, err
+	}
+	return n.bigFloat()
+}
+
+func (p decimalParser) ToBigRat(s string) (*big.Rat, error) {
+	n, err := p.parseReal(s)
+	if err != nil {
+		return nil, err
+	}
+	return n.bigRat()
+}
+
+func (p decimalParser) ToDecimal(s string) (decimal.Decimal, error) {
+	r, err := p.ToBigRat(s)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ratToDecimal(r), nil
+}
+
+func (p decimalParser) ToComplex64(s string) (complex64, error) {
+	if t, ok := p.complexText(s); ok {
+		c, err := strconv.ParseComplex(t, 64)
 		if err != nil {
 			return 0, err
 		}
-		return n, nil
+		return complex64(c), nil
+	}
+	n, err := p.parse(s)
+	if err != nil {
+		return 0, numError("ParseComplex", s, err)
 	}
+	f, err := n.float32()
+	if err != nil {
+		return 0, numError("ParseComplex", s, err)
+	}
+	return complex(f, 0), nil
+}
+
+func (p decimalParser) ToComplex128(s string) (complex128, error) {
+	if t, ok := p.complexText(s); ok {
+		// Parsed directly to keep the sign of zero parts.
+		return strconv.ParseComplex(t, 128)
+	}
+	n, err := p.parse(s)
+	if err != nil {
+		return 0, numError("ParseComplex", s, err)
+	}
+	f, err := n.float64()
+	if err != nil {
+		return 0, numError("ParseComplex", s, err)
+	}
+	return complex(f, n.imag), nil
 }
 
 func (p decimalParser) ToBool(s string) (bool, error) {
-	switch {
-	case strings.ContainsAny(s, "tf"):
-		return strconv.ParseBool(s)
-	case strings.ContainsAny(s, "(i)"):
-		n, err := strconv.ParseComplex(s, 128)
-		if err != nil {
-			return false, err
-		}
-		return real(n) != 0, nil
-	case strings.Contains(s, "/"):
-		n, ok := big.NewRat(0, 1).SetString(s)
-		if !ok {
-			return false, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", s, s)
-		}
-		f, _ := n.Float64()
-		return f != 0, nil
-	default:
-		f, ok := big.NewFloat(0).SetString(s)
-		if !ok {
-			return false, fmt.Errorf("unable to cast %#v of type %T to *big.Float", s, s)
-		}
-		f64, _ := f.Float64()
-		return f64 != 0, nil
-	}
-}
-
-func (p decimalParser) trimPointZeroOfIntString(s string) string {
-	var foundZero bool
-	for i := len(s); i > 0; i-- {
-		switch s[i-1] {
-		case '.':
-			if foundZero {
-				s = s[:i-1]
-				return s
-			}
-		case '0':
-			foundZero = true
-		default:
-			return s
-		}
+	n, err := p.parse(s)
+	if err != nil {
+		return false, numError("ParseBool", s, err)
 	}
-	return s
+	return !n.isZero(), nil
 }
@@ -1,7 +1,13 @@
 package cast_test
 
 import (
+	"errors"
 	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
 )
 
 type testStep struct {
@@ -18,3 +24,79 @@ func isUint(a any) bool {
 	kind := reflect.TypeOf(a).Kind()
 	return kind == reflect.Uint || kind == reflect.Uint8 || kind == reflect.Uint16 || kind == reflect.Uint32 || kind == reflect.Uint64
 }
+
+type (
+	myInt    int
+	myUint8  uint8
+	myFloat  float64
+	myBool   bool
+	myString string
+	myBytes  []byte
+)
+
+// myEnum implements fmt.Stringer like the output of the stringer tool.
+type myEnum int
+
+func (e myEnum) String() string {
+	return [...]string{"zero", "one", "two", "three"}[e]
+}
+
+func TestNamedTypes(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.ToIntE(v) }, myInt(8), int(8), false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, myEnum(3), int(3), false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, myString("8"), int(8), false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, myBytes("8"), int(8), false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, time.Second, int(time.Second), false},
+		{func(v any) (any, error) { return cast.ToInt8E(v) }, myInt(300), int8(0), true},
+		{func(v any) (any, error) { return cast.ToUintE(v) }, myInt(-1), uint(0), true},
+		{func(v any) (any, error) { return cast.ToUint8E(v) }, myUint8(8), uint8(8), false},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, myFloat(8.31), float64(8.31), false},
+		{func(v any) (any, error) { return cast.ToComplex128E(v) }, myFloat(8), complex128(8), false},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, myBool(true), true, false},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, myString("true"), true, false},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, myString("test"), false, true},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, myString("abc"), "abc", false},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, myInt(8), "8", false},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, myEnum(3), "three", false},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, myBytes("abc"), "abc", false},
+		{func(v any) (any, error) { return cast.ToDurationE(v) }, myString("1s"), time.Second, false},
+		{func(v any) (any, error) { return cast.ToDurationE(v) }, myInt(5), time.Duration(5), false},
+		{func(v any) (any, error) { return cast.To[myInt](v) }, "8", myInt(8), false},
+		{func(v any) (any, error) { return cast.To[myEnum](v) }, "3", myEnum(3), false},
+		{func(v any) (any, error) { return cast.To[myUint8](v) }, 300, myUint8(0), true},
+		{func(v any) (any, error) { return cast.To[myFloat](v) }, "8.31", myFloat(8.31), false},
+		{func(v any) (any, error) { return cast.To[myBool](v) }, 1, myBool(true), false},
+		{func(v any) (any, error) { return cast.To[myString](v) }, 8, myString("8"), false},
+		{func(v any) (any, error) { return cast.To[myString](v) }, myInt(8), myString("8"), false},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+			var ce *cast.CastError
+			c.Assert(errors.As(err, &ce), IsTrue, errmsg)
+			c.Assert(ce.Value, Equals, test.input, errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+
+	b, err := cast.To[myBytes](8)
+	c.Assert(err, IsNil)
+	c.Assert(b, DeepEquals, myBytes("8"))
+
+	_, err = cast.To[myInt]("test")
+	c.Assert(err, ErrorMatches, `unable to cast "test" of type string to cast_test.myInt: .*`)
+}
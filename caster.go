@@ -0,0 +1,314 @@
+package cast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// A Caster casts values like the functions of the package, with its own
+// settings. The package-level ToXxxE functions use a Caster with the default
+// settings, so
+//
+//	strict, _ := cast.New(cast.Strict, cast.BoolWords{True: []string{"yes"}, False: []string{"no"}})
+//	n, err := strict.ToIntE("8.5")
+//
+// fails where cast.ToIntE("8.5") returns 8. A Caster is immutable and may be
+// used by multiple goroutines simultaneously.
+type Caster struct {
+	strict      bool
+	rounding    RoundingMode
+	dec         decimalParser
+	location    *time.Location
+	timeFormats []TimeFormat
+	reference   time.Time
+	nils        NilMode
+	floats      FloatFormat
+	complexes   ComplexFormat
+	numbers     *NumberFormat
+	conversions []Conversion
+}
+
+// std is the Caster of the package-level functions.
+var std = &Caster{
+	location:    defaultLocation,
+	timeFormats: defaultTimeFormats,
+}
+
+// New returns a Caster with the default settings changed by args, which may
+// be any of:
+//
+//   - a Mode, Strict failing casts that lose information with ErrInexact.
+//   - a RoundingMode for casts to integer types.
+//   - BoolWords replacing the words accepted as bools in strings.
+//   - Bases restricting the base prefixes accepted by integers in strings.
+//   - a Locale whose numbers are accepted in strings.
+//   - a ProportionMode, Proportions accepting percentages and ratios in
+//     strings.
+//   - a *time.Location and TimeFormat values, as for ToTimeE.
+//   - a time.Time, the reference time of calendar units as for ToDurationE.
+//   - a NilMode selecting how nil values are cast.
+//   - a FloatFormat for casts of floats to text.
+//   - a ComplexFormat for casts of complex numbers to text.
+//   - a NumberFormat for casts of numbers to text; the zero NumberFormat
+//     restores the default formats.
+//   - Conversion values, as returned by Converter, for this Caster only.
+//   - a *Caster, whose settings replace all of the above.
+func New(args ...any) (*Caster, error) {
+	return std.with(args)
+}
+
+// BoolWords is an option of New replacing the strings "t", "true", "f",
+// "false" and their upper-case forms accepted as bools. The words are matched
+// case-insensitively; numbers are still accepted, non-zero being true.
+type BoolWords struct {
+	True  []string
+	False []string
+}
+
+// Bases is an option of New listing the bases whose prefix, "0b" for 2, "0o"
+// for 8 or "0x" for 16, is accepted by integers in strings. Decimal integers
+// are always accepted, so an empty Bases rejects every prefix. Without base 8,
+// integers with a leading zero, such as "0755", are decimal rather than octal.
+type Bases []int
+
+// basePrefixes maps the bases to the letters of their prefixes.
+var basePrefixes = map[int]byte{2: 'b', 8: 'o', 10: 0, 16: 'x'}
+
+// NilMode is an option of New selecting how nil values, including nil
+// pointers, are cast.
+type NilMode int
+
+const (
+	// NilZero casts nil to the zero value of the target type. It is the
+	// default.
+	NilZero NilMode = iota
+	// NilError fails casts of nil with ErrNil, except to an interface type
+	// without methods.
+	NilError
+)
+
+// FloatFormat is an option of New formatting float32 and float64 values cast
+// to text with strconv.FormatFloat, with the format Verb and Precision. The
+// zero FloatFormat formats floats in the shortest decimal form without an
+// exponent, as the package does by default. New rejects a FloatFormat with a
+// Precision but no Verb.
+//
+// Whatever the format, a float32 is written from its own value, so a
+// Precision of -1 gives the fewest digits that identify it as a float32:
+// float32(0.1) is "0.1" rather than "0.10000000149011612". NaN and the
+// infinities are written "NaN", "+Inf" and "-Inf".
+type FloatFormat struct {
+	Verb      byte
+	Precision int
+}
+
+var (
+	// DecimalFloats writes floats in the shortest decimal form that rounds
+	// back to them, without an exponent, such as "1000000000000000000000" or
+	// "0.0000001". It is the default.
+	DecimalFloats = FloatFormat{}
+	// ShortestFloats writes floats in the shortest form that rounds back to
+	// them, with an exponent for large and small magnitudes, such as "1e+21"
+	// or "1e-07".
+	ShortestFloats = FloatFormat{Verb: 'g', Precision: -1}
+)
+
+// FixedFloats returns the FloatFormat writing floats with places digits after
+// the decimal point, such as "3.14" for two places.
+func FixedFloats(places int) FloatFormat {
+	return FloatFormat{Verb: 'f', Precision: places}
+}
+
+// ComplexFormat is an option of New formatting complex64 and complex128
+// values cast to text with strconv.FormatComplex, with the format Verb and
+// Precision, so that the text casts back to the same value. The zero
+// ComplexFormat writes both parts in the shortest form that rounds back to
+// them, such as "(1-2i)" or "(NaN+Infi)". NoParens omits the parentheses, as
+// in "1-2i". New rejects a ComplexFormat with a Precision but no Verb.
+type ComplexFormat struct {
+	Verb      byte
+	Precision int
+	NoParens  bool
+}
+
+// with returns a copy of c with args applied, or c itself when there are none.
+func (c *Caster) with(args []any) (*Caster, error) {
+	if len(args) == 0 {
+		return c, nil
+	}
+
+	d := *c
+	var timeFormats []TimeFormat
+	for _, arg := range args {
+		if f, ok := arg.(TimeFormat); ok {
+			timeFormats = append(timeFormats, f)
+			continue
+		}
+		if !d.apply(arg) {
+			return nil, unsupportedOption(arg)
+		}
+	}
+	if len(timeFormats) > 0 {
+		d.timeFormats = timeFormats
+	}
+	return &d, nil
+}
+
+// apply sets the option arg, reporting whether it is one.
+func (c *Caster) apply(arg any) bool {
+	switch v := arg.(type) {
+	case Mode:
+		switch v {
+		case Lenient, Strict:
+			c.strict = v == Strict
+			return true
+		}
+	case RoundingMode:
+		if v >= RoundTruncate && v <= RoundHalfAwayFromZero {
+			c.rounding = v
+			return true
+		}
+	case BoolWords:
+		words := make(map[string]bool, len(v.True)+len(v.False))
+		for _, w := range v.False {
+			words[strings.ToLower(w)] = false
+		}
+		for _, w := range v.True {
+			words[strings.ToLower(w)] = true
+		}
+		c.dec.words = words
+		return true
+	case Bases:
+		rejected := "box"
+		for _, base := range v {
+			p, ok := basePrefixes[base]
+			if !ok {
+				return false
+			}
+			rejected = strings.ReplaceAll(rejected, string(p), "")
+		}
+		c.dec.rejected = rejected
+		return true
+	case Locale:
+		if v.isZero() {
+			c.dec.locale = nil
+			return true
+		}
+		if v.valid() {
+			v.Grouping = append([]int(nil), v.Grouping...)
+			c.dec.locale = &v
+			return true
+		}
+	case ProportionMode:
+		if v == NoProportions || v == Proportions {
+			c.dec.proportions = v == Proportions
+			return true
+		}
+	case *time.Location:
+		c.location = v
+		if v == nil {
+			c.location = defaultLocation
+		}
+		return true
+	case time.Time:
+		c.reference = v
+		return true
+	case NilMode:
+		if v == NilZero || v == NilError {
+			c.nils = v
+			return true
+		}
+	case FloatFormat:
+		if v == (FloatFormat{}) || strings.IndexByte("beEfgGxX", v.Verb) >= 0 {
+			c.floats = v
+			return true
+		}
+	case ComplexFormat:
+		if v.Verb == 0 && v.Precision == 0 || strings.IndexByte("beEfgGxX", v.Verb) >= 0 {
+			c.complexes = v
+			return true
+		}
+	case NumberFormat:
+		if v.isZero() {
+			c.numbers = nil
+			return true
+		}
+		if v.valid() {
+			if v.Locale.isZero() {
+				v.Locale = defaultNumberLocale
+			}
+			v.Locale.Grouping = append([]int(nil), v.Locale.Grouping...)
+			c.numbers = &v
+			return true
+		}
+	case Conversion:
+		if v.fn != nil {
+			c.conversions = addConversion(c.conversions, v)
+			return true
+		}
+	case *Caster:
+		if v != nil {
+			*c = *v
+			return true
+		}
+	}
+	return false
+}
+
+func unsupportedOption(arg any) error {
+	return fmt.Errorf("unsupported option %#v of type %T", arg, arg)
+}
+
+// castTo casts a to the type T with the settings of c.
+func castTo[T any](c *Caster, a any) (T, error) {
+	v, err := c.toType(a, reflect.TypeOf((*T)(nil)).Elem())
+	t, _ := v.(T)
+	return t, err
+}
+
+// castBuiltin casts a to the type T with cast, the type switch of the casts to
+// T, when a is a builtin value that the settings of c handled by toType leave
+// alone and no conversion from its type to T is registered, and with castTo
+// otherwise. Unsupported values go through castTo for the other conversions.
+func castBuiltin[T any](c *Caster, a any, cast func(*Caster, any) (T, error)) (T, error) {
+	if c.strict || c.rounding != RoundTruncate || !isBuiltin(a) {
+		return castTo[T](c, a)
+	}
+	if _, _, ok := c.exactConversion(a, reflect.TypeOf((*T)(nil)).Elem()); !ok {
+		v, err := cast(c, a)
+		if err == nil || errorKind(err) != ErrUnsupported {
+			return v, err
+		}
+	}
+	return castTo[T](c, a)
+}
+
+// isBuiltin reports whether a is a non-nil value of a type with a dedicated
+// cast and no standard conversion interface to honour.
+func isBuiltin(a any) bool {
+	switch v := a.(type) {
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, complex64, complex128, string, []byte,
+		json.Number, decimal.Decimal, time.Time, time.Duration:
+		return true
+	case *big.Int:
+		return v != nil
+	case *big.Float:
+		return v != nil
+	case *big.Rat:
+		return v != nil
+	}
+	return false
+}
+
+// isNil reports whether a is nil or a nil pointer.
+func isNil(a any) bool {
+	v := reflect.ValueOf(indirect(a))
+	return !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil()
+}
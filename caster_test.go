@@ -0,0 +1,148 @@
+package cast_test
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+func TestCaster(t *testing.T) {
+	c := New(t)
+
+	words := cast.BoolWords{True: []string{"yes", "on"}, False: []string{"no", "off"}}
+	tests := []struct {
+		args   []any
+		tove   func(*cast.Caster, any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{nil, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "8.5", 8, false},
+		{[]any{cast.Strict}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "8.5", 0, true},
+		{[]any{cast.RoundHalfEven}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "8.5", 8, false},
+		{[]any{cast.RoundHalfUp}, func(c *cast.Caster, v any) (any, error) { return c.ToInt64E(v) }, 8.5, int64(9), false},
+		{[]any{words}, func(c *cast.Caster, v any) (any, error) { return c.ToBoolE(v) }, "Yes", true, false},
+		{[]any{words}, func(c *cast.Caster, v any) (any, error) { return c.ToBoolE(v) }, "off", false, false},
+		{[]any{words}, func(c *cast.Caster, v any) (any, error) { return c.ToBoolE(v) }, "1", true, false},
+		{[]any{words}, func(c *cast.Caster, v any) (any, error) { return c.ToBoolE(v) }, "true", false, true},
+		{[]any{words}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "on", 1, false},
+		{[]any{cast.Bases{16}}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "0x10", 16, false},
+		{[]any{cast.Bases{16}}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "0b10", 0, true},
+		{[]any{cast.Bases{}}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "-0x10", 0, true},
+		{[]any{cast.Bases{}}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "0010", 10, false},
+		{[]any{cast.Bases{16}}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "0755", 755, false},
+		{[]any{cast.Bases{16}}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "089", 89, false},
+		{[]any{cast.Bases{8}}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "0755", 493, false},
+		{[]any{cast.NilError}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, nil, 0, true},
+		{[]any{cast.NilError}, func(c *cast.Caster, v any) (any, error) { return c.ToStringE(v) }, (*int)(nil), "", true},
+		{[]any{cast.NilZero}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, (*int)(nil), 0, false},
+		{[]any{cast.NilZero}, func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, (*big.Rat)(nil), 0.0, false},
+		{[]any{cast.NilError}, func(c *cast.Caster, v any) (any, error) { return c.ToIntSliceE(v) }, nil, []int(nil), true},
+		{[]any{cast.NilZero}, func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, nil, 0, false},
+		{[]any{cast.FloatFormat{Verb: 'f', Precision: 2}}, func(c *cast.Caster, v any) (any, error) { return c.ToStringE(v) }, 1.005, "1.00", false},
+		{[]any{cast.FloatFormat{Verb: 'e', Precision: -1}}, func(c *cast.Caster, v any) (any, error) { return c.ToStringE(v) }, float32(0.1), "1e-01", false},
+		{[]any{cast.FloatFormat{Verb: 'g', Precision: 3}}, func(c *cast.Caster, v any) (any, error) { return c.ToStringSliceE(v) }, []float64{1.2345}, []string{"1.23"}, false},
+		{[]any{time.FixedZone("UTC+1", 3600)}, func(c *cast.Caster, v any) (any, error) { return c.ToTimeE(v) }, "2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.FixedZone("UTC+1", 3600)), false},
+		{[]any{cast.TimeFormat{Format: "02/01/2006"}}, func(c *cast.Caster, v any) (any, error) { return c.ToTimeE(v) }, "02/01/2024", time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local), false},
+		{[]any{cast.Strict}, func(c *cast.Caster, v any) (any, error) { return c.ToStringMapIntE(v) }, `{"a": 1.5}`, map[string]int(nil), true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		caster, err := cast.New(test.args...)
+		c.Assert(err, IsNil, errmsg)
+		v, err := test.tove(caster, test.input)
+		if test.iserr {
+			c.Assert(err, Not(IsNil), errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, DeepEquals, test.expect, errmsg)
+	}
+}
+
+func TestCasterOptions(t *testing.T) {
+	c := New(t)
+
+	for _, arg := range []any{cast.Bases{3}, cast.NilMode(2), cast.FloatFormat{Verb: 'v'}, cast.FloatFormat{Precision: 2}, cast.ComplexFormat{Precision: -1}, (*cast.Caster)(nil), "nope"} {
+		_, err := cast.New(arg)
+		c.Assert(err, ErrorMatches, `unsupported option .*`, Commentf("arg = %#v", arg))
+	}
+
+	_, err := cast.New(cast.Strict, cast.RoundCeil, cast.Bases{2, 8, 10, 16}, time.UTC, time.Time{})
+	c.Assert(err, IsNil)
+
+	strict, err := cast.New(cast.Strict, cast.NilError)
+	c.Assert(err, IsNil)
+
+	// The package functions keep the default settings.
+	n, err := cast.ToIntE("8.5")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 8)
+
+	_, err = strict.ToIntE(nil)
+	c.Assert(errors.Is(err, cast.ErrNil), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast <nil> of type <nil> to int: nil value`)
+
+	// A Caster passed to To, or to New, supplies all its settings.
+	_, err = cast.To[int]("8.5", strict)
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+	n, err = cast.To[int]("8.5", strict, cast.Lenient, cast.RoundCeil)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 9)
+	derived, err := cast.New(strict, cast.NilZero)
+	c.Assert(err, IsNil)
+	_, err = derived.ToIntE(nil)
+	c.Assert(err, IsNil)
+	_, err = derived.ToIntE(8.5)
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+
+	var out struct{ Port int }
+	err = strict.Decode(map[string]any{"port": "8.5"}, &out)
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+
+	// The options of ToTimeE and ToDurationE override those of the Caster.
+	paris, err := cast.New(time.FixedZone("CET", 3600))
+	c.Assert(err, IsNil)
+	tm, err := paris.ToTimeE(0, time.UTC)
+	c.Assert(err, IsNil)
+	c.Assert(tm.Location(), Equals, time.UTC)
+	_, err = paris.ToDurationE("1d", time.UTC)
+	c.Assert(err, ErrorMatches, `unsupported option .* of type \*time.Location`)
+}
+
+func TestCasterConcurrent(t *testing.T) {
+	c := New(t)
+
+	strict, err := cast.New(cast.Strict)
+	c.Assert(err, IsNil)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2*50)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[2*i] = strict.ToIntE("8.5")
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[2*i+1] = cast.ToIntE("8.5")
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if i%2 == 0 {
+			c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+		} else {
+			c.Assert(err, IsNil)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package cast
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
+
+	"github.com/shopspring/decimal"
 )
 
 // ToInt casts an interface to an int type.
@@ -14,88 +17,17 @@ func ToInt(i any) int {
 
 // ToIntE casts an interface to an int type.
 func ToIntE(a any) (int, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToIntE(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		return v, nil
-	case int8:
-		return int(v), nil
-	case int16:
-		return int(v), nil
-	case int32:
-		return int(v), nil
-	case int64:
-		return int(v), nil
-	case uint:
-		return int(v), nil
-	case uint8:
-		return int(v), nil
-	case uint16:
-		return int(v), nil
-	case uint32:
-		return int(v), nil
-	case uint64:
-		return int(v), nil
-	case float32:
-		return int(v), nil
-	case float64:
-		return int(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-		}
-		return int(v.Int64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-		}
-		n, _ := v.Int64()
-		return int(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-		}
-		n, _ := v.Float64()
-		return int(n), nil
-	case complex64:
-		return int(real(v)), nil
-	case complex128:
-		return int(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToInt(v)
-		if err == nil {
-			return int(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-	case []byte:
-		n, err := dec.ToInt(string(v))
-		if err == nil {
-			return int(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-	case fmt.Stringer:
-		n, err := dec.ToInt(v.String())
-		if err == nil {
-			return int(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-	case error:
-		n, err := dec.ToInt(v.Error())
-		if err == nil {
-			return int(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int", a, a)
-	}
+// ToIntE casts an interface to an int type with the settings of c.
+func (c *Caster) ToIntE(a any) (int, error) {
+	return castBuiltin(c, a, (*Caster).toIntE)
+}
+
+func (c *Caster) toIntE(a any) (int, error) {
+	n, err := c.toSignedE(a, math.MinInt, math.MaxInt, "int")
+	return int(n), err
 }
 
 // ToInt8 casts an interface to an int8 type.
@@ -106,88 +38,17 @@ func ToInt8(i any) int8 {
 
 // ToInt8E casts an interface to an int8 type.
 func ToInt8E(a any) (int8, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToInt8E(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		return int8(v), nil
-	case int8:
-		return v, nil
-	case int16:
-		return int8(v), nil
-	case int32:
-		return int8(v), nil
-	case int64:
-		return int8(v), nil
-	case uint:
-		return int8(v), nil
-	case uint8:
-		return int8(v), nil
-	case uint16:
-		return int8(v), nil
-	case uint32:
-		return int8(v), nil
-	case uint64:
-		return int8(v), nil
-	case float32:
-		return int8(v), nil
-	case float64:
-		return int8(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-		}
-		return int8(v.Int64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-		}
-		n, _ := v.Int64()
-		return int8(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-		}
-		n, _ := v.Float64()
-		return int8(n), nil
-	case complex64:
-		return int8(real(v)), nil
-	case complex128:
-		return int8(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToInt(v)
-		if err == nil {
-			return int8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-	case []byte:
-		n, err := dec.ToInt(string(v))
-		if err == nil {
-			return int8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-	case fmt.Stringer:
-		n, err := dec.ToInt(v.String())
-		if err == nil {
-			return int8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-	case error:
-		n, err := dec.ToInt(v.Error())
-		if err == nil {
-			return int8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", v, v)
-	}
+// ToInt8E casts an interface to an int8 type with the settings of c.
+func (c *Caster) ToInt8E(a any) (int8, error) {
+	return castBuiltin(c, a, (*Caster).toInt8E)
+}
+
+func (c *Caster) toInt8E(a any) (int8, error) {
+	n, err := c.toSignedE(a, math.MinInt8, math.MaxInt8, "int8")
+	return int8(n), err
 }
 
 // ToInt16 casts an interface to an int16 type.
@@ -198,88 +59,17 @@ func ToInt16(i any) int16 {
 
 // ToInt16E casts an interface to an int16 type.
 func ToInt16E(a any) (int16, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToInt16E(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		return int16(v), nil
-	case int8:
-		return int16(v), nil
-	case int16:
-		return v, nil
-	case int32:
-		return int16(v), nil
-	case int64:
-		return int16(v), nil
-	case uint:
-		return int16(v), nil
-	case uint8:
-		return int16(v), nil
-	case uint16:
-		return int16(v), nil
-	case uint32:
-		return int16(v), nil
-	case uint64:
-		return int16(v), nil
-	case float32:
-		return int16(v), nil
-	case float64:
-		return int16(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-		}
-		return int16(v.Int64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-		}
-		n, _ := v.Int64()
-		return int16(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-		}
-		n, _ := v.Float64()
-		return int16(n), nil
-	case complex64:
-		return int16(real(v)), nil
-	case complex128:
-		return int16(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToInt(v)
-		if err == nil {
-			return int16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-	case []byte:
-		n, err := dec.ToInt(string(v))
-		if err == nil {
-			return int16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-	case fmt.Stringer:
-		n, err := dec.ToInt(v.String())
-		if err == nil {
-			return int16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-	case error:
-		n, err := dec.ToInt(v.Error())
-		if err == nil {
-			return int16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", a, a)
-	}
+// ToInt16E casts an interface to an int16 type with the settings of c.
+func (c *Caster) ToInt16E(a any) (int16, error) {
+	return castBuiltin(c, a, (*Caster).toInt16E)
+}
+
+func (c *Caster) toInt16E(a any) (int16, error) {
+	n, err := c.toSignedE(a, math.MinInt16, math.MaxInt16, "int16")
+	return int16(n), err
 }
 
 // ToInt32 casts an interface to an int32 type.
@@ -290,88 +80,17 @@ func ToInt32(i any) int32 {
 
 // ToInt32E casts an interface to an int32 type.
 func ToInt32E(a any) (int32, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToInt32E(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		return int32(v), nil
-	case int8:
-		return int32(v), nil
-	case int16:
-		return int32(v), nil
-	case int32:
-		return v, nil
-	case int64:
-		return int32(v), nil
-	case uint:
-		return int32(v), nil
-	case uint8:
-		return int32(v), nil
-	case uint16:
-		return int32(v), nil
-	case uint32:
-		return int32(v), nil
-	case uint64:
-		return int32(v), nil
-	case float32:
-		return int32(v), nil
-	case float64:
-		return int32(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-		}
-		return int32(v.Int64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-		}
-		n, _ := v.Int64()
-		return int32(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-		}
-		n, _ := v.Float64()
-		return int32(n), nil
-	case complex64:
-		return int32(real(v)), nil
-	case complex128:
-		return int32(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToInt(v)
-		if err == nil {
-			return int32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-	case []byte:
-		n, err := dec.ToInt(string(v))
-		if err == nil {
-			return int32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-	case fmt.Stringer:
-		n, err := dec.ToInt(v.String())
-		if err == nil {
-			return int32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-	case error:
-		n, err := dec.ToInt(v.Error())
-		if err == nil {
-			return int32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", a, a)
-	}
+// ToInt32E casts an interface to an int32 type with the settings of c.
+func (c *Caster) ToInt32E(a any) (int32, error) {
+	return castBuiltin(c, a, (*Caster).toInt32E)
+}
+
+func (c *Caster) toInt32E(a any) (int32, error) {
+	n, err := c.toSignedE(a, math.MinInt32, math.MaxInt32, "int32")
+	return int32(n), err
 }
 
 // ToInt64 casts an interface to an int64 type.
@@ -382,88 +101,115 @@ func ToInt64(i any) int64 {
 
 // ToInt64E casts an interface to an int64 type.
 func ToInt64E(a any) (int64, error) {
+	return std.ToInt64E(a)
+}
+
+// ToInt64E casts an interface to an int64 type with the settings of c.
+func (c *Caster) ToInt64E(a any) (int64, error) {
+	return castBuiltin(c, a, (*Caster).toInt64E)
+}
+
+func (c *Caster) toInt64E(a any) (int64, error) {
+	return c.toSignedE(a, math.MinInt64, math.MaxInt64, "int64")
+}
+
+// toSignedE casts an interface to an int64 type, failing when the value does
+// not fit in [min, max], the range of the signed integer type named by to.
+func (c *Caster) toSignedE(a any, min, max int64, to string) (int64, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toSignedE(b, min, max, to)
+		return n, wrapError(a, to, err)
+	}
 
+	var (
+		n   int64
+		err error
+	)
 	switch v := a.(type) {
 	case int:
-		return int64(v), nil
+		n = int64(v)
 	case int8:
-		return int64(v), nil
+		n = int64(v)
 	case int16:
-		return int64(v), nil
+		n = int64(v)
 	case int32:
-		return int64(v), nil
+		n = int64(v)
 	case int64:
-		return v, nil
+		n = v
 	case uint:
-		return int64(v), nil
+		n, err = uint64ToInt64(uint64(v), a, to)
 	case uint8:
-		return int64(v), nil
+		n = int64(v)
 	case uint16:
-		return int64(v), nil
+		n = int64(v)
 	case uint32:
-		return int64(v), nil
+		n = int64(v)
 	case uint64:
-		return int64(v), nil
+		n, err = uint64ToInt64(v, a, to)
 	case float32:
-		return int64(v), nil
+		n, err = float64ToInt64(float64(v), a, to)
 	case float64:
-		return int64(v), nil
+		n, err = float64ToInt64(v, a, to)
 	case *big.Int:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+			return 0, newCastError(a, to, ErrUnsupported, nil)
 		}
-		return v.Int64(), nil
+		n, err = bigIntToInt64(v, a, to)
 	case *big.Float:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+			return 0, newCastError(a, to, ErrUnsupported, nil)
 		}
-		n, _ := v.Int64()
-		return n, nil
+		if v.IsInf() {
+			return 0, newCastError(a, to, ErrNotFinite, nil)
+		}
+		i, _ := v.Int(nil)
+		n, err = bigIntToInt64(i, a, to)
 	case *big.Rat:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+			return 0, newCastError(a, to, ErrUnsupported, nil)
 		}
-		n, _ := v.Float64()
-		return int64(n), nil
+		n, err = bigIntToInt64(ratToBigInt(v), a, to)
+	case decimal.Decimal:
+		n, err = bigIntToInt64(v.BigInt(), a, to)
+	case *decimal.Decimal:
+		if v == nil {
+			return 0, newCastError(a, to, ErrUnsupported, nil)
+		}
+		n, err = bigIntToInt64(v.BigInt(), a, to)
 	case complex64:
-		return int64(real(v)), nil
+		n, err = float64ToInt64(float64(real(v)), a, to)
 	case complex128:
-		return int64(real(v)), nil
+		n, err = float64ToInt64(real(v), a, to)
 	case bool:
 		if v {
-			return 1, nil
+			n = 1
 		}
-		return 0, nil
 	case string:
-		n, err := dec.ToInt(v)
-		if err == nil {
-			return n, nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+		n, err = c.parseInt64(v, a, to)
 	case []byte:
-		n, err := dec.ToInt(string(v))
-		if err == nil {
-			return n, nil
+		n, err = c.parseInt64(string(v), a, to)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return 0, newCastError(a, to, ErrSyntax, nil)
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+		n, err = c.parseInt64(string(v), a, to)
 	case fmt.Stringer:
-		n, err := dec.ToInt(v.String())
-		if err == nil {
-			return n, nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+		n, err = c.parseInt64(v.String(), a, to)
 	case error:
-		n, err := dec.ToInt(v.Error())
-		if err == nil {
-			return n, nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+		n, err = c.parseInt64(v.Error(), a, to)
 	case nil:
 		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", a, a)
+		return 0, newCastError(a, to, ErrUnsupported, nil)
+	}
+	if err != nil {
+		return 0, err
 	}
+	if n < min || n > max {
+		return 0, overflowError(a, to)
+	}
+	return n, nil
 }
 
 // ToUint casts an interface to a uint type.
@@ -474,136 +220,17 @@ func ToUint(i any) uint {
 
 // ToUintE casts an interface to a uint type.
 func ToUintE(a any) (uint, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToUintE(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v), nil
-	case int8:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v), nil
-	case int16:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v), nil
-	case int32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v), nil
-	case int64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v), nil
-	case uint:
-		return v, nil
-	case uint8:
-		return uint(v), nil
-	case uint16:
-		return uint(v), nil
-	case uint32:
-		return uint(v), nil
-	case uint64:
-		return uint(v), nil
-	case float32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v), nil
-	case float64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(v.Uint64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		n, _ := v.Uint64()
-		return uint(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		n, _ := v.Float64()
-		return uint(n), nil
-	case complex64:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(real(v)), nil
-	case complex128:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-		}
-		return uint(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToUint(v)
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-			}
-			return uint(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-	case []byte:
-		n, err := dec.ToUint(string(v))
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-			}
-			return uint(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-	case fmt.Stringer:
-		n, err := dec.ToUint(v.String())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-			}
-			return uint(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-	case error:
-		n, err := dec.ToUint(v.Error())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint", v, v)
-			}
-			return uint(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint", a, a)
-	}
+// ToUintE casts an interface to a uint type with the settings of c.
+func (c *Caster) ToUintE(a any) (uint, error) {
+	return castBuiltin(c, a, (*Caster).toUintE)
+}
+
+func (c *Caster) toUintE(a any) (uint, error) {
+	n, err := c.toUnsignedE(a, math.MaxUint, "uint")
+	return uint(n), err
 }
 
 // ToUint8 casts an interface to a uint8 type.
@@ -612,138 +239,19 @@ func ToUint8(i any) uint8 {
 	return v
 }
 
-// ToUint8E casts an interface to a uint type.
+// ToUint8E casts an interface to a uint8 type.
 func ToUint8E(a any) (uint8, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToUint8E(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v), nil
-	case int8:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v), nil
-	case int16:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v), nil
-	case int32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v), nil
-	case int64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v), nil
-	case uint:
-		return uint8(v), nil
-	case uint8:
-		return v, nil
-	case uint16:
-		return uint8(v), nil
-	case uint32:
-		return uint8(v), nil
-	case uint64:
-		return uint8(v), nil
-	case float32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v), nil
-	case float64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(v.Uint64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		n, _ := v.Uint64()
-		return uint8(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		n, _ := v.Float64()
-		return uint8(n), nil
-	case complex64:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(real(v)), nil
-	case complex128:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-		}
-		return uint8(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToUint(v)
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-			}
-			return uint8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-	case []byte:
-		n, err := dec.ToUint(string(v))
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-			}
-			return uint8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-	case fmt.Stringer:
-		n, err := dec.ToUint(v.String())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-			}
-			return uint8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-	case error:
-		n, err := dec.ToUint(v.Error())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", v, v)
-			}
-			return uint8(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", a, a)
-	}
+// ToUint8E casts an interface to a uint8 type with the settings of c.
+func (c *Caster) ToUint8E(a any) (uint8, error) {
+	return castBuiltin(c, a, (*Caster).toUint8E)
+}
+
+func (c *Caster) toUint8E(a any) (uint8, error) {
+	n, err := c.toUnsignedE(a, math.MaxUint8, "uint8")
+	return uint8(n), err
 }
 
 // ToUint16 casts an interface to a uint16 type.
@@ -754,136 +262,17 @@ func ToUint16(i any) uint16 {
 
 // ToUint16E casts an interface to a uint16 type.
 func ToUint16E(a any) (uint16, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToUint16E(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v), nil
-	case int8:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v), nil
-	case int16:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v), nil
-	case int32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v), nil
-	case int64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v), nil
-	case uint:
-		return uint16(v), nil
-	case uint8:
-		return uint16(v), nil
-	case uint16:
-		return v, nil
-	case uint32:
-		return uint16(v), nil
-	case uint64:
-		return uint16(v), nil
-	case float32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v), nil
-	case float64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(v.Uint64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		n, _ := v.Uint64()
-		return uint16(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		n, _ := v.Float64()
-		return uint16(n), nil
-	case complex64:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(real(v)), nil
-	case complex128:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-		}
-		return uint16(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToUint(v)
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-			}
-			return uint16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-	case []byte:
-		n, err := dec.ToUint(string(v))
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-			}
-			return uint16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-	case fmt.Stringer:
-		n, err := dec.ToUint(v.String())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-			}
-			return uint16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-	case error:
-		n, err := dec.ToUint(v.Error())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", v, v)
-			}
-			return uint16(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", a, a)
-	}
+// ToUint16E casts an interface to a uint16 type with the settings of c.
+func (c *Caster) ToUint16E(a any) (uint16, error) {
+	return castBuiltin(c, a, (*Caster).toUint16E)
+}
+
+func (c *Caster) toUint16E(a any) (uint16, error) {
+	n, err := c.toUnsignedE(a, math.MaxUint16, "uint16")
+	return uint16(n), err
 }
 
 // ToUint32 casts an interface to a uint32 type.
@@ -894,136 +283,17 @@ func ToUint32(i any) uint32 {
 
 // ToUint32E casts an interface to a uint32 type.
 func ToUint32E(a any) (uint32, error) {
-	a = indirectToStringerOrError(a)
+	return std.ToUint32E(a)
+}
 
-	switch v := a.(type) {
-	case int:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v), nil
-	case int8:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v), nil
-	case int16:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v), nil
-	case int32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v), nil
-	case int64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v), nil
-	case uint:
-		return uint32(v), nil
-	case uint8:
-		return uint32(v), nil
-	case uint16:
-		return uint32(v), nil
-	case uint32:
-		return v, nil
-	case uint64:
-		return uint32(v), nil
-	case float32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v), nil
-	case float64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v), nil
-	case *big.Int:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(v.Uint64()), nil
-	case *big.Float:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		n, _ := v.Uint64()
-		return uint32(n), nil
-	case *big.Rat:
-		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		n, _ := v.Float64()
-		return uint32(n), nil
-	case complex64:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(real(v)), nil
-	case complex128:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-		}
-		return uint32(real(v)), nil
-	case bool:
-		if v {
-			return 1, nil
-		}
-		return 0, nil
-	case string:
-		n, err := dec.ToUint(v)
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-			}
-			return uint32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-	case []byte:
-		n, err := dec.ToUint(string(v))
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-			}
-			return uint32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-	case fmt.Stringer:
-		n, err := dec.ToUint(v.String())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-			}
-			return uint32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-	case error:
-		n, err := dec.ToUint(v.Error())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", v, v)
-			}
-			return uint32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-	case nil:
-		return 0, nil
-	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", a, a)
-	}
+// ToUint32E casts an interface to a uint32 type with the settings of c.
+func (c *Caster) ToUint32E(a any) (uint32, error) {
+	return castBuiltin(c, a, (*Caster).toUint32E)
+}
+
+func (c *Caster) toUint32E(a any) (uint32, error) {
+	n, err := c.toUnsignedE(a, math.MaxUint32, "uint32")
+	return uint32(n), err
 }
 
 // ToUint64 casts an interface to a uint64 type.
@@ -1034,136 +304,217 @@ func ToUint64(i any) uint64 {
 
 // ToUint64E casts an interface to a uint64 type.
 func ToUint64E(a any) (uint64, error) {
+	return std.ToUint64E(a)
+}
+
+// ToUint64E casts an interface to a uint64 type with the settings of c.
+func (c *Caster) ToUint64E(a any) (uint64, error) {
+	return castBuiltin(c, a, (*Caster).toUint64E)
+}
+
+func (c *Caster) toUint64E(a any) (uint64, error) {
+	return c.toUnsignedE(a, math.MaxUint64, "uint64")
+}
+
+// toUnsignedE casts an interface to a uint64 type, failing when the value is
+// negative or greater than max, the largest value of the unsigned integer type
+// named by to.
+func (c *Caster) toUnsignedE(a any, max uint64, to string) (uint64, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toUnsignedE(b, max, to)
+		return n, wrapError(a, to, err)
+	}
 
+	var (
+		n   uint64
+		err error
+	)
 	switch v := a.(type) {
 	case int:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(v), nil
+		n, err = int64ToUint64(int64(v), a, to)
 	case int8:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(v), nil
+		n, err = int64ToUint64(int64(v), a, to)
 	case int16:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(v), nil
+		n, err = int64ToUint64(int64(v), a, to)
 	case int32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(v), nil
+		n, err = int64ToUint64(int64(v), a, to)
 	case int64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(v), nil
+		n, err = int64ToUint64(v, a, to)
 	case uint:
-		return uint64(v), nil
+		n = uint64(v)
 	case uint8:
-		return uint64(v), nil
+		n = uint64(v)
 	case uint16:
-		return uint64(v), nil
+		n = uint64(v)
 	case uint32:
-		return uint64(v), nil
+		n = uint64(v)
 	case uint64:
-		return v, nil
+		n = v
 	case float32:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(v), nil
+		n, err = float64ToUint64(float64(v), a, to)
 	case float64:
-		if v < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(v), nil
+		n, err = float64ToUint64(v, a, to)
 	case *big.Int:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
-		}
-		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
+			return 0, newCastError(a, to, ErrUnsupported, nil)
 		}
-		return v.Uint64(), nil
+		n, err = bigIntToUint64(v, a, to)
 	case *big.Float:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
+			return 0, newCastError(a, to, ErrUnsupported, nil)
+		}
+		if v.IsInf() {
+			return 0, newCastError(a, to, ErrNotFinite, nil)
 		}
 		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
+			return 0, newCastError(a, to, ErrNegative, nil)
 		}
-		n, _ := v.Uint64()
-		return n, nil
+		i, _ := v.Int(nil)
+		n, err = bigIntToUint64(i, a, to)
 	case *big.Rat:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
+			return 0, newCastError(a, to, ErrUnsupported, nil)
 		}
 		if v.Sign() < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
+			return 0, newCastError(a, to, ErrNegative, nil)
 		}
-		n, _ := v.Float64()
-		return uint64(n), nil
-	case complex64:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
+		n, err = bigIntToUint64(ratToBigInt(v), a, to)
+	case decimal.Decimal:
+		n, err = decimalToUint64(v, a, to)
+	case *decimal.Decimal:
+		if v == nil {
+			return 0, newCastError(a, to, ErrUnsupported, nil)
 		}
-		return uint64(real(v)), nil
+		n, err = decimalToUint64(*v, a, to)
+	case complex64:
+		n, err = float64ToUint64(float64(real(v)), a, to)
 	case complex128:
-		if real(v) < 0 {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-		}
-		return uint64(real(v)), nil
+		n, err = float64ToUint64(real(v), a, to)
 	case bool:
 		if v {
-			return 1, nil
+			n = 1
 		}
-		return 0, nil
 	case string:
-		n, err := dec.ToUint(v)
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-			}
-			return uint64(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
+		n, err = c.parseUint64(v, a, to)
 	case []byte:
-		n, err := dec.ToUint(string(v))
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-			}
-			return uint64(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
+		n, err = c.parseUint64(string(v), a, to)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return 0, newCastError(a, to, ErrSyntax, nil)
+		}
+		n, err = c.parseUint64(string(v), a, to)
 	case fmt.Stringer:
-		n, err := dec.ToUint(v.String())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-			}
-			return uint64(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
+		n, err = c.parseUint64(v.String(), a, to)
 	case error:
-		n, err := dec.ToUint(v.Error())
-		if err == nil {
-			if n < 0 {
-				return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", v, v)
-			}
-			return uint64(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
+		n, err = c.parseUint64(v.Error(), a, to)
 	case nil:
 		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", a, a)
+		return 0, newCastError(a, to, ErrUnsupported, nil)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n > max {
+		return 0, overflowError(a, to)
+	}
+	return n, nil
+}
+
+// overflowError reports that a does not fit in the type named by to.
+func overflowError(a any, to string) error {
+	return newCastError(a, to, ErrOverflow, nil)
+}
+
+func uint64ToInt64(n uint64, a any, to string) (int64, error) {
+	if n > math.MaxInt64 {
+		return 0, overflowError(a, to)
+	}
+	return int64(n), nil
+}
+
+func int64ToUint64(n int64, a any, to string) (uint64, error) {
+	if n < 0 {
+		return 0, newCastError(a, to, ErrNegative, nil)
+	}
+	return uint64(n), nil
+}
+
+// float64ToInt64 truncates f toward zero.
+func float64ToInt64(f float64, a any, to string) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, newCastError(a, to, ErrNotFinite, nil)
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, overflowError(a, to)
+	}
+	return int64(f), nil
+}
+
+// float64ToUint64 truncates f toward zero.
+func float64ToUint64(f float64, a any, to string) (uint64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, newCastError(a, to, ErrNotFinite, nil)
+	}
+	if f < 0 {
+		return 0, newCastError(a, to, ErrNegative, nil)
 	}
+	if f >= math.MaxUint64 {
+		return 0, overflowError(a, to)
+	}
+	return uint64(f), nil
+}
+
+// decimalToUint64 truncates d toward zero.
+func decimalToUint64(d decimal.Decimal, a any, to string) (uint64, error) {
+	if d.Sign() < 0 {
+		return 0, newCastError(a, to, ErrNegative, nil)
+	}
+	return bigIntToUint64(d.BigInt(), a, to)
+}
+
+func bigIntToInt64(n *big.Int, a any, to string) (int64, error) {
+	if !n.IsInt64() {
+		return 0, overflowError(a, to)
+	}
+	return n.Int64(), nil
+}
+
+func bigIntToUint64(n *big.Int, a any, to string) (uint64, error) {
+	if n.Sign() < 0 {
+		return 0, newCastError(a, to, ErrNegative, nil)
+	}
+	if !n.IsUint64() {
+		return 0, overflowError(a, to)
+	}
+	return n.Uint64(), nil
+}
+
+func (c *Caster) parseInt64(s string, a any, to string) (int64, error) {
+	n, err := c.dec.ToInt(s)
+	if err != nil {
+		return 0, wrapError(a, to, err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseUint64(s string, a any, to string) (uint64, error) {
+	n, err := c.dec.ToUint(s)
+	if err != nil {
+		return 0, wrapError(a, to, err)
+	}
+	return n, nil
+}
+
+// float64ToFloat32 converts f to a float32, failing when a finite f is too
+// large in magnitude to be represented.
+func float64ToFloat32(f float64, a any, to string) (float32, error) {
+	n := float32(f)
+	if math.IsInf(float64(n), 0) && !math.IsInf(f, 0) {
+		return 0, overflowError(a, to)
+	}
+	return n, nil
 }
 
 // ToFloat32 casts an interface to a float32 type.
@@ -1174,7 +525,20 @@ func ToFloat32(i any) float32 {
 
 // ToFloat32E casts an interface to a float32 type.
 func ToFloat32E(a any) (float32, error) {
+	return std.ToFloat32E(a)
+}
+
+// ToFloat32E casts an interface to a float32 type with the settings of c.
+func (c *Caster) ToFloat32E(a any) (float32, error) {
+	return castBuiltin(c, a, (*Caster).toFloat32E)
+}
+
+func (c *Caster) toFloat32E(a any) (float32, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toFloat32E(b)
+		return n, wrapError(a, "float32", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1200,62 +564,63 @@ func ToFloat32E(a any) (float32, error) {
 	case float32:
 		return v, nil
 	case float64:
-		return float32(v), nil
+		return float64ToFloat32(v, a, "float32")
 	case *big.Int:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+			return 0, newCastError(a, "float32", ErrUnsupported, nil)
 		}
 		n, _ := new(big.Float).SetInt(v).Float32()
-		return n, nil
+		return bigFloat32(n, a, "float32")
 	case *big.Float:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+			return 0, newCastError(a, "float32", ErrUnsupported, nil)
 		}
 		n, _ := v.Float32()
+		if !v.IsInf() {
+			return bigFloat32(n, a, "float32")
+		}
 		return n, nil
 	case *big.Rat:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+			return 0, newCastError(a, "float32", ErrUnsupported, nil)
 		}
 		n, _ := v.Float32()
-		return n, nil
+		return bigFloat32(n, a, "float32")
+	case decimal.Decimal:
+		n, _ := v.Rat().Float32()
+		return bigFloat32(n, a, "float32")
+	case *decimal.Decimal:
+		if v == nil {
+			return 0, newCastError(a, "float32", ErrUnsupported, nil)
+		}
+		n, _ := v.Rat().Float32()
+		return bigFloat32(n, a, "float32")
 	case complex64:
 		return float32(real(v)), nil
 	case complex128:
-		return float32(real(v)), nil
+		return float64ToFloat32(real(v), a, "float32")
 	case bool:
 		if v {
 			return 1, nil
 		}
 		return 0, nil
 	case string:
-		n, err := dec.ToFloat32(v)
-		if err == nil {
-			return float32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+		return c.parseFloat32(v, a, "float32")
 	case []byte:
-		n, err := dec.ToFloat32(string(v))
-		if err == nil {
-			return float32(n), nil
+		return c.parseFloat32(string(v), a, "float32")
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return 0, newCastError(a, "float32", ErrSyntax, nil)
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+		return c.parseFloat32(string(v), a, "float32")
 	case fmt.Stringer:
-		n, err := dec.ToFloat32(v.String())
-		if err == nil {
-			return float32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+		return c.parseFloat32(v.String(), a, "float32")
 	case error:
-		n, err := dec.ToFloat32(v.Error())
-		if err == nil {
-			return float32(n), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+		return c.parseFloat32(v.Error(), a, "float32")
 	case nil:
 		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", a, a)
+		return 0, newCastError(a, "float32", ErrUnsupported, nil)
 	}
 }
 
@@ -1267,7 +632,20 @@ func ToFloat64(i any) float64 {
 
 // ToFloat64E casts an interface to a float64 type.
 func ToFloat64E(a any) (float64, error) {
+	return std.ToFloat64E(a)
+}
+
+// ToFloat64E casts an interface to a float64 type with the settings of c.
+func (c *Caster) ToFloat64E(a any) (float64, error) {
+	return castBuiltin(c, a, (*Caster).toFloat64E)
+}
+
+func (c *Caster) toFloat64E(a any) (float64, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toFloat64E(b)
+		return n, wrapError(a, "float64", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1296,22 +674,34 @@ func ToFloat64E(a any) (float64, error) {
 		return v, nil
 	case *big.Int:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+			return 0, newCastError(a, "float64", ErrUnsupported, nil)
 		}
 		n, _ := new(big.Float).SetInt(v).Float64()
-		return n, nil
+		return bigFloat64(n, a, "float64")
 	case *big.Float:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+			return 0, newCastError(a, "float64", ErrUnsupported, nil)
 		}
 		n, _ := v.Float64()
+		if !v.IsInf() {
+			return bigFloat64(n, a, "float64")
+		}
 		return n, nil
 	case *big.Rat:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+			return 0, newCastError(a, "float64", ErrUnsupported, nil)
 		}
 		n, _ := v.Float64()
-		return n, nil
+		return bigFloat64(n, a, "float64")
+	case decimal.Decimal:
+		n, _ := v.Rat().Float64()
+		return bigFloat64(n, a, "float64")
+	case *decimal.Decimal:
+		if v == nil {
+			return 0, newCastError(a, "float64", ErrUnsupported, nil)
+		}
+		n, _ := v.Rat().Float64()
+		return bigFloat64(n, a, "float64")
 	case complex64:
 		return float64(real(v)), nil
 	case complex128:
@@ -1322,36 +712,59 @@ func ToFloat64E(a any) (float64, error) {
 		}
 		return 0, nil
 	case string:
-		n, err := dec.ToFloat64(v)
-		if err == nil {
-			return n, nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+		return c.parseFloat64(v, a, "float64")
 	case []byte:
-		n, err := dec.ToFloat64(string(v))
-		if err == nil {
-			return n, nil
+		return c.parseFloat64(string(v), a, "float64")
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return 0, newCastError(a, "float64", ErrSyntax, nil)
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+		return c.parseFloat64(string(v), a, "float64")
 	case fmt.Stringer:
-		n, err := dec.ToFloat64(v.String())
-		if err == nil {
-			return n, nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+		return c.parseFloat64(v.String(), a, "float64")
 	case error:
-		n, err := dec.ToFloat64(v.Error())
-		if err == nil {
-			return n, nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+		return c.parseFloat64(v.Error(), a, "float64")
 	case nil:
 		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", a, a)
+		return 0, newCastError(a, "float64", ErrUnsupported, nil)
 	}
 }
 
+// bigFloat32 rejects the infinity produced when a finite arbitrary precision
+// value is too large for a float32.
+func bigFloat32(n float32, a any, to string) (float32, error) {
+	if math.IsInf(float64(n), 0) {
+		return 0, overflowError(a, to)
+	}
+	return n, nil
+}
+
+// bigFloat64 rejects the infinity produced when a finite arbitrary precision
+// value is too large for a float64.
+func bigFloat64(n float64, a any, to string) (float64, error) {
+	if math.IsInf(n, 0) {
+		return 0, overflowError(a, to)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseFloat32(s string, a any, to string) (float32, error) {
+	n, err := c.dec.ToFloat32(s)
+	if err != nil {
+		return 0, wrapError(a, to, err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseFloat64(s string, a any, to string) (float64, error) {
+	n, err := c.dec.ToFloat64(s)
+	if err != nil {
+		return 0, wrapError(a, to, err)
+	}
+	return n, nil
+}
+
 // ToBigInt casts an interface to a *big.Int type.
 func ToBigInt(i any) *big.Int {
 	v, _ := ToBigIntE(i)
@@ -1360,7 +773,20 @@ func ToBigInt(i any) *big.Int {
 
 // ToBigIntE casts an interface to a *big.Int type.
 func ToBigIntE(a any) (*big.Int, error) {
+	return std.ToBigIntE(a)
+}
+
+// ToBigIntE casts an interface to a *big.Int type with the settings of c.
+func (c *Caster) ToBigIntE(a any) (*big.Int, error) {
+	return castBuiltin(c, a, (*Caster).toBigIntE)
+}
+
+func (c *Caster) toBigIntE(a any) (*big.Int, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toBigIntE(b)
+		return n, wrapError(a, "*big.Int", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1385,54 +811,68 @@ func ToBigIntE(a any) (*big.Int, error) {
 		return big.NewInt(0).SetUint64(v), nil
 	case float32:
 		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
-			return big.NewInt(0), fmt.Errorf("unable to cast %#v of type %T to big.Float", a, a)
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrNotFinite, nil)
 		}
 		n, _ := big.NewFloat(float64(v)).Int(nil)
 		return n, nil
 	case float64:
 		if math.IsInf(v, 0) || math.IsNaN(v) {
-			return big.NewInt(0), fmt.Errorf("unable to cast %#v of type %T to big.Float", a, a)
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrNotFinite, nil)
 		}
 		n, _ := big.NewFloat(v).Int(nil)
 		return n, nil
 	case *big.Int:
 		if v == nil {
-			return big.NewInt(0), fmt.Errorf("unable to cast %#v of type %T to *big.Int", a, a)
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrUnsupported, nil)
 		}
 		return v, nil
 	case *big.Float:
 		if v == nil {
-			return big.NewInt(0), fmt.Errorf("unable to cast %#v of type %T to *big.Int", a, a)
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrUnsupported, nil)
+		}
+		if v.IsInf() {
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrNotFinite, nil)
 		}
 		n, _ := v.Int(nil)
 		return n, nil
 	case *big.Rat:
 		if v == nil {
-			return big.NewInt(0), fmt.Errorf("unable to cast %#v of type %T to *big.Int", a, a)
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrUnsupported, nil)
 		}
-		n, _ := v.Float64()
-		return big.NewInt(int64(n)), nil
+		return ratToBigInt(v), nil
+	case decimal.Decimal:
+		return v.BigInt(), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrUnsupported, nil)
+		}
+		return v.BigInt(), nil
 	case complex64:
-		return big.NewInt(0).SetInt64(int64(real(v))), nil
+		return float64ToBigInt(float64(real(v)), a, "*big.Int")
 	case complex128:
-		return big.NewInt(0).SetInt64(int64(real(v))), nil
+		return float64ToBigInt(real(v), a, "*big.Int")
 	case bool:
 		if v {
 			return big.NewInt(1), nil
 		}
 		return big.NewInt(0), nil
 	case string:
-		return dec.ToBigInt(v)
+		return c.parseBigInt(v, a)
 	case []byte:
-		return dec.ToBigInt(string(v))
+		return c.parseBigInt(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return big.NewInt(0), newCastError(a, "*big.Int", ErrSyntax, nil)
+		}
+		return c.parseBigInt(string(v), a)
 	case fmt.Stringer:
-		return dec.ToBigInt(v.String())
+		return c.parseBigInt(v.String(), a)
 	case error:
-		return dec.ToBigInt(v.Error())
+		return c.parseBigInt(v.Error(), a)
 	case nil:
 		return big.NewInt(0), nil
 	default:
-		return big.NewInt(0), fmt.Errorf("unable to cast %#v of type %T to *big.Int", a, a)
+		return big.NewInt(0), newCastError(a, "*big.Int", ErrUnsupported, nil)
 	}
 }
 
@@ -1444,7 +884,20 @@ func ToBigFloat(i any) *big.Float {
 
 // ToBigFloatE casts an interface to a *big.Float type.
 func ToBigFloatE(a any) (*big.Float, error) {
+	return std.ToBigFloatE(a)
+}
+
+// ToBigFloatE casts an interface to a *big.Float type with the settings of c.
+func (c *Caster) ToBigFloatE(a any) (*big.Float, error) {
+	return castBuiltin(c, a, (*Caster).toBigFloatE)
+}
+
+func (c *Caster) toBigFloatE(a any) (*big.Float, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toBigFloatE(b)
+		return n, wrapError(a, "*big.Float", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1469,51 +922,68 @@ func ToBigFloatE(a any) (*big.Float, error) {
 		return big.NewFloat(0).SetUint64(v), nil
 	case float32:
 		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
-			return big.NewFloat(0), fmt.Errorf("unable to cast %#v of type %T to big.Float", a, a)
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrNotFinite, nil)
 		}
 		return big.NewFloat(float64(v)), nil
 	case float64:
 		if math.IsInf(v, 0) || math.IsNaN(v) {
-			return big.NewFloat(0), fmt.Errorf("unable to cast %#v of type %T to big.Float", a, a)
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrNotFinite, nil)
 		}
 		return big.NewFloat(v), nil
 	case *big.Int:
 		if v == nil {
-			return big.NewFloat(0), fmt.Errorf("unable to cast %#v of type %T to *big.Float", a, a)
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrUnsupported, nil)
 		}
 		return big.NewFloat(0).SetInt(v), nil
 	case *big.Float:
 		if v == nil {
-			return big.NewFloat(0), fmt.Errorf("unable to cast %#v of type %T to *big.Float", a, a)
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrUnsupported, nil)
 		}
 		return v, nil
 	case *big.Rat:
 		if v == nil {
-			return big.NewFloat(0), fmt.Errorf("unable to cast %#v of type %T to *big.Float", a, a)
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrUnsupported, nil)
 		}
-		n, _ := v.Float64()
-		return big.NewFloat(n), nil
+		return new(big.Float).SetRat(v), nil
+	case decimal.Decimal:
+		return new(big.Float).SetRat(v.Rat()), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrUnsupported, nil)
+		}
+		return new(big.Float).SetRat(v.Rat()), nil
 	case complex64:
-		return big.NewFloat(0).SetInt64(int64(real(v))), nil
+		if math.IsInf(float64(real(v)), 0) || math.IsNaN(float64(real(v))) {
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrNotFinite, nil)
+		}
+		return big.NewFloat(float64(real(v))), nil
 	case complex128:
-		return big.NewFloat(0).SetInt64(int64(real(v))), nil
+		if math.IsInf(real(v), 0) || math.IsNaN(real(v)) {
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrNotFinite, nil)
+		}
+		return big.NewFloat(real(v)), nil
 	case bool:
 		if v {
 			return big.NewFloat(1), nil
 		}
 		return big.NewFloat(0), nil
 	case string:
-		return dec.ToBigFloat(v)
+		return c.parseBigFloat(v, a)
 	case []byte:
-		return dec.ToBigFloat(string(v))
+		return c.parseBigFloat(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return big.NewFloat(0), newCastError(a, "*big.Float", ErrSyntax, nil)
+		}
+		return c.parseBigFloat(string(v), a)
 	case fmt.Stringer:
-		return dec.ToBigFloat(v.String())
+		return c.parseBigFloat(v.String(), a)
 	case error:
-		return dec.ToBigFloat(v.Error())
+		return c.parseBigFloat(v.Error(), a)
 	case nil:
 		return big.NewFloat(0), nil
 	default:
-		return big.NewFloat(0), fmt.Errorf("unable to cast %#v of type %T to *big.Float", a, a)
+		return big.NewFloat(0), newCastError(a, "*big.Float", ErrUnsupported, nil)
 	}
 }
 
@@ -1525,7 +995,20 @@ func ToBigRat(i any) *big.Rat {
 
 // ToBigRatE casts an interface to a *big.Rat type.
 func ToBigRatE(a any) (*big.Rat, error) {
+	return std.ToBigRatE(a)
+}
+
+// ToBigRatE casts an interface to a *big.Rat type with the settings of c.
+func (c *Caster) ToBigRatE(a any) (*big.Rat, error) {
+	return castBuiltin(c, a, (*Caster).toBigRatE)
+}
+
+func (c *Caster) toBigRatE(a any) (*big.Rat, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toBigRatE(b)
+		return n, wrapError(a, "*big.Rat", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1539,7 +1022,7 @@ func ToBigRatE(a any) (*big.Rat, error) {
 	case int64:
 		return big.NewRat(v, 1), nil
 	case uint:
-		return big.NewRat(int64(v), 1), nil
+		return big.NewRat(0, 1).SetUint64(uint64(v)), nil
 	case uint8:
 		return big.NewRat(int64(v), 1), nil
 	case uint16:
@@ -1547,36 +1030,52 @@ func ToBigRatE(a any) (*big.Rat, error) {
 	case uint32:
 		return big.NewRat(int64(v), 1), nil
 	case uint64:
-		return big.NewRat(int64(v), 1), nil
+		return big.NewRat(0, 1).SetUint64(v), nil
 	case float32:
 		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
-			return big.NewRat(0, 1), fmt.Errorf("unable to cast %#v of type %T to big.Rat", a, a)
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrNotFinite, nil)
 		}
 		return big.NewRat(0, 1).SetFloat64(float64(v)), nil
 	case float64:
 		if math.IsInf(v, 0) || math.IsNaN(v) {
-			return big.NewRat(0, 1), fmt.Errorf("unable to cast %#v of type %T to big.Rat", a, a)
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrNotFinite, nil)
 		}
 		return big.NewRat(0, 1).SetFloat64(v), nil
 	case *big.Int:
 		if v == nil {
-			return big.NewRat(0, 1), fmt.Errorf("unable to cast %#v of type %T to *big.Rat", a, a)
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrUnsupported, nil)
 		}
-		return big.NewRat(0, 1).SetFloat64(float64(v.Int64())), nil
+		return big.NewRat(0, 1).SetInt(v), nil
 	case *big.Float:
 		if v == nil {
-			return big.NewRat(0, 1), fmt.Errorf("unable to cast %#v of type %T to *big.Rat", a, a)
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrUnsupported, nil)
 		}
-		n, _ := v.Float64()
-		return big.NewRat(0, 1).SetFloat64(n), nil
+		if v.IsInf() {
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrNotFinite, nil)
+		}
+		n, _ := v.Rat(nil)
+		return n, nil
 	case *big.Rat:
 		if v == nil {
-			return big.NewRat(0, 1), fmt.Errorf("unable to cast %#v of type %T to *big.Rat", a, a)
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrUnsupported, nil)
 		}
 		return v, nil
+	case decimal.Decimal:
+		return v.Rat(), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrUnsupported, nil)
+		}
+		return v.Rat(), nil
 	case complex64:
+		if math.IsInf(float64(real(v)), 0) || math.IsNaN(float64(real(v))) {
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrNotFinite, nil)
+		}
 		return big.NewRat(0, 1).SetFloat64(float64(real(v))), nil
 	case complex128:
+		if math.IsInf(real(v), 0) || math.IsNaN(real(v)) {
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrNotFinite, nil)
+		}
 		return big.NewRat(0, 1).SetFloat64(real(v)), nil
 	case bool:
 		if v {
@@ -1584,17 +1083,141 @@ func ToBigRatE(a any) (*big.Rat, error) {
 		}
 		return big.NewRat(0, 1), nil
 	case string:
-		return dec.ToBigRat(v)
+		return c.parseBigRat(v, a)
 	case []byte:
-		return dec.ToBigRat(string(v))
+		return c.parseBigRat(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrSyntax, nil)
+		}
+		return c.parseBigRat(string(v), a)
 	case fmt.Stringer:
-		return dec.ToBigRat(v.String())
+		return c.parseBigRat(v.String(), a)
 	case error:
-		return dec.ToBigRat(v.Error())
+		return c.parseBigRat(v.Error(), a)
 	case nil:
 		return big.NewRat(0, 1), nil
 	default:
-		return big.NewRat(0, 1), fmt.Errorf("unable to cast %#v of type %T to *big.Rat", a, a)
+		return big.NewRat(0, 1), newCastError(a, "*big.Rat", ErrUnsupported, nil)
+	}
+}
+
+// ToDecimal casts an interface to a decimal.Decimal type.
+func ToDecimal(i any) decimal.Decimal {
+	v, _ := ToDecimalE(i)
+	return v
+}
+
+// ToDecimalE casts an interface to a decimal.Decimal type.
+func ToDecimalE(a any) (decimal.Decimal, error) {
+	return std.ToDecimalE(a)
+}
+
+// ToDecimalE casts an interface to a decimal.Decimal type with the settings of c.
+func (c *Caster) ToDecimalE(a any) (decimal.Decimal, error) {
+	return castBuiltin(c, a, (*Caster).toDecimalE)
+}
+
+func (c *Caster) toDecimalE(a any) (decimal.Decimal, error) {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toDecimalE(b)
+		return n, wrapError(a, "decimal.Decimal", err)
+	}
+
+	switch v := a.(type) {
+	case int:
+		return decimal.NewFromInt(int64(v)), nil
+	case int8:
+		return decimal.NewFromInt(int64(v)), nil
+	case int16:
+		return decimal.NewFromInt(int64(v)), nil
+	case int32:
+		return decimal.NewFromInt(int64(v)), nil
+	case int64:
+		return decimal.NewFromInt(v), nil
+	case uint:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(uint64(v)), 0), nil
+	case uint8:
+		return decimal.NewFromInt(int64(v)), nil
+	case uint16:
+		return decimal.NewFromInt(int64(v)), nil
+	case uint32:
+		return decimal.NewFromInt(int64(v)), nil
+	case uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(v), 0), nil
+	case float32:
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrNotFinite, nil)
+		}
+		return decimal.NewFromFloat32(v), nil
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrNotFinite, nil)
+		}
+		return decimal.NewFromFloat(v), nil
+	case *big.Int:
+		if v == nil {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrUnsupported, nil)
+		}
+		return decimal.NewFromBigInt(v, 0), nil
+	case *big.Float:
+		if v == nil {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrUnsupported, nil)
+		}
+		if v.IsInf() {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrNotFinite, nil)
+		}
+		// Like NewFromFloat, keep the shortest decimal that rounds back to v.
+		n, err := decimal.NewFromString(v.Text('e', -1))
+		if err != nil {
+			return decimal.Zero, overflowError(a, "decimal.Decimal")
+		}
+		return n, nil
+	case *big.Rat:
+		if v == nil {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrUnsupported, nil)
+		}
+		return ratToDecimal(v), nil
+	case decimal.Decimal:
+		return v, nil
+	case *decimal.Decimal:
+		if v == nil {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrUnsupported, nil)
+		}
+		return *v, nil
+	case complex64:
+		if math.IsInf(float64(real(v)), 0) || math.IsNaN(float64(real(v))) {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrNotFinite, nil)
+		}
+		return decimal.NewFromFloat32(real(v)), nil
+	case complex128:
+		if math.IsInf(real(v), 0) || math.IsNaN(real(v)) {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrNotFinite, nil)
+		}
+		return decimal.NewFromFloat(real(v)), nil
+	case bool:
+		if v {
+			return decimal.NewFromInt(1), nil
+		}
+		return decimal.Zero, nil
+	case string:
+		return c.parseDecimal(v, a)
+	case []byte:
+		return c.parseDecimal(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return decimal.Zero, newCastError(a, "decimal.Decimal", ErrSyntax, nil)
+		}
+		return c.parseDecimal(string(v), a)
+	case fmt.Stringer:
+		return c.parseDecimal(v.String(), a)
+	case error:
+		return c.parseDecimal(v.Error(), a)
+	case nil:
+		return decimal.Zero, nil
+	default:
+		return decimal.Zero, newCastError(a, "decimal.Decimal", ErrUnsupported, nil)
 	}
 }
 
@@ -1606,7 +1229,20 @@ func ToComplex64(i any) complex64 {
 
 // ToComplex64E casts an interface to a complex64 type.
 func ToComplex64E(a any) (complex64, error) {
+	return std.ToComplex64E(a)
+}
+
+// ToComplex64E casts an interface to a complex64 type with the settings of c.
+func (c *Caster) ToComplex64E(a any) (complex64, error) {
+	return castBuiltin(c, a, (*Caster).toComplex64E)
+}
+
+func (c *Caster) toComplex64E(a any) (complex64, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toComplex64E(b)
+		return n, wrapError(a, "complex64", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1632,46 +1268,77 @@ func ToComplex64E(a any) (complex64, error) {
 	case float32:
 		return complex(v, 0), nil
 	case float64:
-		return complex(float32(v), 0), nil
+		n, err := float64ToFloat32(v, a, "complex64")
+		return complex(n, 0), err
 	case *big.Int:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to complex64", a, a)
+			return 0, newCastError(a, "complex64", ErrUnsupported, nil)
 		}
 		n, _ := new(big.Float).SetInt(v).Float32()
-		return complex(n, 0), nil
+		n, err := bigFloat32(n, a, "complex64")
+		return complex(n, 0), err
 	case *big.Float:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to complex64", a, a)
+			return 0, newCastError(a, "complex64", ErrUnsupported, nil)
 		}
 		n, _ := v.Float32()
+		if !v.IsInf() {
+			n, err := bigFloat32(n, a, "complex64")
+			return complex(n, 0), err
+		}
 		return complex(n, 0), nil
 	case *big.Rat:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to complex64", a, a)
+			return 0, newCastError(a, "complex64", ErrUnsupported, nil)
 		}
-		n, _ := new(big.Float).SetRat(v).Float32()
-		return complex(n, 0), nil
+		n, _ := v.Float32()
+		n, err := bigFloat32(n, a, "complex64")
+		return complex(n, 0), err
+	case decimal.Decimal:
+		n, _ := v.Rat().Float32()
+		n, err := bigFloat32(n, a, "complex64")
+		return complex(n, 0), err
+	case *decimal.Decimal:
+		if v == nil {
+			return 0, newCastError(a, "complex64", ErrUnsupported, nil)
+		}
+		n, _ := v.Rat().Float32()
+		n, err := bigFloat32(n, a, "complex64")
+		return complex(n, 0), err
 	case complex64:
 		return v, nil
 	case complex128:
-		return complex64(v), nil
+		r, err := float64ToFloat32(real(v), a, "complex64")
+		if err != nil {
+			return 0, err
+		}
+		i, err := float64ToFloat32(imag(v), a, "complex64")
+		if err != nil {
+			return 0, err
+		}
+		return complex(r, i), nil
 	case bool:
 		if v {
 			return complex(1, 0), nil
 		}
 		return complex(0, 0), nil
 	case string:
-		return dec.ToComplex64(v)
+		return c.parseComplex64(v, a)
 	case []byte:
-		return dec.ToComplex64(string(v))
+		return c.parseComplex64(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return 0, newCastError(a, "complex64", ErrSyntax, nil)
+		}
+		return c.parseComplex64(string(v), a)
 	case fmt.Stringer:
-		return dec.ToComplex64(v.String())
+		return c.parseComplex64(v.String(), a)
 	case error:
-		return dec.ToComplex64(v.Error())
+		return c.parseComplex64(v.Error(), a)
 	case nil:
 		return complex(0, 0), nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to complex64", a, a)
+		return 0, newCastError(a, "complex64", ErrUnsupported, nil)
 	}
 }
 
@@ -1683,7 +1350,20 @@ func ToComplex128(i any) complex128 {
 
 // ToComplex128E casts an interface to a complex128 type.
 func ToComplex128E(a any) (complex128, error) {
+	return std.ToComplex128E(a)
+}
+
+// ToComplex128E casts an interface to a complex128 type with the settings of c.
+func (c *Caster) ToComplex128E(a any) (complex128, error) {
+	return castBuiltin(c, a, (*Caster).toComplex128E)
+}
+
+func (c *Caster) toComplex128E(a any) (complex128, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toComplex128E(b)
+		return n, wrapError(a, "complex128", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1714,22 +1394,39 @@ func ToComplex128E(a any) (complex128, error) {
 		return complex(v, 0), nil
 	case *big.Int:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to complex128", a, a)
+			return 0, newCastError(a, "complex128", ErrUnsupported, nil)
 		}
 		n, _ := new(big.Float).SetInt(v).Float64()
-		return complex(n, 0), nil
+		n, err := bigFloat64(n, a, "complex128")
+		return complex(n, 0), err
 	case *big.Float:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to complex128", a, a)
+			return 0, newCastError(a, "complex128", ErrUnsupported, nil)
 		}
 		n, _ := v.Float64()
+		if !v.IsInf() {
+			n, err := bigFloat64(n, a, "complex128")
+			return complex(n, 0), err
+		}
 		return complex(n, 0), nil
 	case *big.Rat:
 		if v == nil {
-			return 0, fmt.Errorf("unable to cast %#v of type %T to complex128", a, a)
+			return 0, newCastError(a, "complex128", ErrUnsupported, nil)
 		}
-		n, _ := new(big.Float).SetRat(v).Float64()
-		return complex(n, 0), nil
+		n, _ := v.Float64()
+		n, err := bigFloat64(n, a, "complex128")
+		return complex(n, 0), err
+	case decimal.Decimal:
+		n, _ := v.Rat().Float64()
+		n, err := bigFloat64(n, a, "complex128")
+		return complex(n, 0), err
+	case *decimal.Decimal:
+		if v == nil {
+			return 0, newCastError(a, "complex128", ErrUnsupported, nil)
+		}
+		n, _ := v.Rat().Float64()
+		n, err := bigFloat64(n, a, "complex128")
+		return complex(n, 0), err
 	case complex64:
 		return complex128(v), nil
 	case complex128:
@@ -1740,17 +1437,22 @@ func ToComplex128E(a any) (complex128, error) {
 		}
 		return complex(0, 0), nil
 	case string:
-		return dec.ToComplex128(v)
+		return c.parseComplex128(v, a)
 	case []byte:
-		return dec.ToComplex128(string(v))
+		return c.parseComplex128(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return 0, newCastError(a, "complex128", ErrSyntax, nil)
+		}
+		return c.parseComplex128(string(v), a)
 	case fmt.Stringer:
-		return dec.ToComplex128(v.String())
+		return c.parseComplex128(v.String(), a)
 	case error:
-		return dec.ToComplex128(v.Error())
+		return c.parseComplex128(v.Error(), a)
 	case nil:
 		return complex(0, 0), nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to complex128", a, a)
+		return 0, newCastError(a, "complex128", ErrUnsupported, nil)
 	}
 }
 
@@ -1762,7 +1464,20 @@ func ToBool(i any) bool {
 
 // ToBoolE casts an interface to a bool type.
 func ToBoolE(a any) (bool, error) {
+	return std.ToBoolE(a)
+}
+
+// ToBoolE casts an interface to a bool type with the settings of c.
+func (c *Caster) ToBoolE(a any) (bool, error) {
+	return castBuiltin(c, a, (*Caster).toBoolE)
+}
+
+func (c *Caster) toBoolE(a any) (bool, error) {
 	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toBoolE(b)
+		return n, wrapError(a, "bool", err)
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -1791,19 +1506,26 @@ func ToBoolE(a any) (bool, error) {
 		return v != 0, nil
 	case *big.Int:
 		if v == nil {
-			return false, fmt.Errorf("unable to cast %#v of type %T to bool", a, a)
+			return false, newCastError(a, "bool", ErrUnsupported, nil)
 		}
 		return v.Sign() != 0, nil
 	case *big.Float:
 		if v == nil {
-			return false, fmt.Errorf("unable to cast %#v of type %T to bool", a, a)
+			return false, newCastError(a, "bool", ErrUnsupported, nil)
 		}
 		return v.Sign() != 0, nil
 	case *big.Rat:
 		if v == nil {
-			return false, fmt.Errorf("unable to cast %#v of type %T to bool", a, a)
+			return false, newCastError(a, "bool", ErrUnsupported, nil)
 		}
 		return v.Sign() != 0, nil
+	case decimal.Decimal:
+		return !v.IsZero(), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return false, newCastError(a, "bool", ErrUnsupported, nil)
+		}
+		return !v.IsZero(), nil
 	case complex64:
 		return real(v) != 0 || imag(v) != 0, nil
 	case complex128:
@@ -1811,16 +1533,127 @@ func ToBoolE(a any) (bool, error) {
 	case bool:
 		return v, nil
 	case string:
-		return dec.ToBool(v)
+		return c.parseBool(v, a)
 	case []byte:
-		return dec.ToBool(string(v))
+		return c.parseBool(string(v), a)
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return false, newCastError(a, "bool", ErrSyntax, nil)
+		}
+		return c.parseBool(string(v), a)
 	case fmt.Stringer:
-		return dec.ToBool(v.String())
+		return c.parseBool(v.String(), a)
 	case error:
-		return dec.ToBool(v.Error())
+		return c.parseBool(v.Error(), a)
 	case nil:
 		return false, nil
 	default:
-		return false, fmt.Errorf("unable to cast %#v of type %T to bool", a, a)
+		return false, newCastError(a, "bool", ErrUnsupported, nil)
+	}
+}
+
+// ratToBigInt truncates r toward zero.
+func ratToBigInt(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+// ratToDecimal returns r exactly when it has a finite decimal expansion, that
+// is when its denominator has no prime factors other than 2 and 5, and rounded
+// to decimal.DivisionPrecision places otherwise.
+func ratToDecimal(r *big.Rat) decimal.Decimal {
+	num := decimal.NewFromBigInt(r.Num(), 0)
+	if r.IsInt() {
+		return num
+	}
+
+	d := new(big.Int).Set(r.Denom())
+	twos := int32(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+	var fives int32
+	five, q, m := big.NewInt(5), new(big.Int), new(big.Int)
+	for {
+		q.QuoRem(d, five, m)
+		if m.Sign() != 0 {
+			break
+		}
+		d.Set(q)
+		fives++
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return num.Div(decimal.NewFromBigInt(r.Denom(), 0))
+	}
+
+	// r = num * (10^exp / denom) * 10^-exp, where the quotient is exact.
+	exp := twos
+	if fives > exp {
+		exp = fives
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+	scale.Quo(scale, r.Denom())
+	return decimal.NewFromBigInt(scale.Mul(scale, r.Num()), -exp)
+}
+
+// float64ToBigInt truncates f toward zero.
+func float64ToBigInt(f float64, a any, to string) (*big.Int, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return big.NewInt(0), newCastError(a, to, ErrNotFinite, nil)
+	}
+	n, _ := big.NewFloat(f).Int(nil)
+	return n, nil
+}
+
+func (c *Caster) parseBigInt(s string, a any) (*big.Int, error) {
+	n, err := c.dec.ToBigInt(s)
+	if err != nil {
+		return big.NewInt(0), wrapError(a, "*big.Int", err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseBigFloat(s string, a any) (*big.Float, error) {
+	n, err := c.dec.ToBigFloat(s)
+	if err != nil {
+		return big.NewFloat(0), wrapError(a, "*big.Float", err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseBigRat(s string, a any) (*big.Rat, error) {
+	n, err := c.dec.ToBigRat(s)
+	if err != nil {
+		return big.NewRat(0, 1), wrapError(a, "*big.Rat", err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseDecimal(s string, a any) (decimal.Decimal, error) {
+	n, err := c.dec.ToDecimal(s)
+	if err != nil {
+		return decimal.Zero, wrapError(a, "decimal.Decimal", err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseComplex64(s string, a any) (complex64, error) {
+	n, err := c.dec.ToComplex64(s)
+	if err != nil {
+		return 0, wrapError(a, "complex64", err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseComplex128(s string, a any) (complex128, error) {
+	n, err := c.dec.ToComplex128(s)
+	if err != nil {
+		return 0, wrapError(a, "complex128", err)
+	}
+	return n, nil
+}
+
+func (c *Caster) parseBool(s string, a any) (bool, error) {
+	n, err := c.dec.ToBool(s)
+	if err != nil {
+		return false, wrapError(a, "bool", err)
 	}
+	return n, nil
 }
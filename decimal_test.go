@@ -3,12 +3,15 @@ package cast_test
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	. "github.com/frankban/quicktest"
 	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
 )
 
 func runDecimalTest(c *C, tests []testStep, tove func(any) (any, error), tov func(any) any) {
@@ -42,6 +45,10 @@ func runDecimalTest(c *C, tests []testStep, tove func(any) (any, error), tov fun
 	}
 }
 
+func ptrDecimal(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
 func createDecimalTestSteps(t testValues) []testStep {
 	isUint := isUint(t.zero)
 
@@ -62,6 +69,8 @@ func createDecimalTestSteps(t testValues) []testStep {
 		{big.NewInt(8), t.eight, false},
 		{big.NewFloat(8.31), t.eightpoint31, false},
 		{big.NewRat(8, 1), t.eight, false},
+		{decimal.NewFromInt(8), t.eight, false},
+		{ptrDecimal(decimal.NewFromInt(8)), t.eight, false},
 		{complex64(8 + 0i), t.eight, false},
 		{complex128(8 + 0i), t.eight, false},
 		{true, t.one, false},
@@ -84,6 +93,7 @@ func createDecimalTestSteps(t testValues) []testStep {
 		{big.NewInt(-8), t.eightnegative, isUint},
 		{big.NewFloat(-8.31), t.eightpoint31negative, isUint},
 		{big.NewRat(-8, 1), t.eightnegative, isUint},
+		{decimal.NewFromInt(-8), t.eightnegative, isUint},
 		{complex64(-8 + 0i), t.eightnegative, isUint},
 		{complex128(-8 + 0i), t.eightnegative, isUint},
 		{false, t.zero, false},
@@ -406,6 +416,231 @@ func TestToBigRatE(t *testing.T) {
 	)
 }
 
+func TestToDecimalE(t *testing.T) {
+	tests := createDecimalTestSteps(testValues{
+		decimal.Zero,
+		decimal.NewFromInt(1),
+		decimal.NewFromInt(8),
+		decimal.NewFromInt(-8),
+		decimal.RequireFromString("8.31"),
+		decimal.RequireFromString("-8.31"),
+		decimal.RequireFromString("8.31"),
+		decimal.RequireFromString("-8.31"),
+	})
+
+	runDecimalTest(
+		New(t),
+		tests,
+		func(v any) (any, error) { return cast.ToDecimalE(v) },
+		func(v any) any { return cast.ToDecimal(v) },
+	)
+}
+
+func TestDecimal(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		input  any
+		expect string
+		iserr  bool
+	}{
+		{"0.1", "0.1", false},
+		{"12345678901234567890.123456789", "12345678901234567890.123456789", false},
+		{"1/8", "0.125", false},
+		{"-3/20", "-0.15", false},
+		{"1/3", "0.3333333333333333", false},
+		{big.NewRat(1, 1024), "0.0009765625", false},
+		{new(big.Int).Lsh(big.NewInt(1), 70), "1180591620717411303424", false},
+		{uint64(math.MaxUint64), "18446744073709551615", false},
+		{0.1, "0.1", false},
+		{math.NaN(), "", true},
+		{math.Inf(1), "", true},
+		{"inf", "", true},
+		{(*decimal.Decimal)(nil), "0", false},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+		v, err := cast.ToDecimalE(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v.String(), Equals, test.expect, errmsg)
+	}
+
+	// Decimal inputs keep every digit rather than going through float64.
+	d := decimal.RequireFromString("12345678901234567890.5")
+	n, err := cast.ToBigIntE(d)
+	c.Assert(err, IsNil)
+	c.Assert(n.String(), Equals, "12345678901234567890")
+
+	r, err := cast.ToBigRatE(&d)
+	c.Assert(err, IsNil)
+	c.Assert(r.String(), Equals, "24691357802469135781/2")
+
+	s, err := cast.ToStringE(d)
+	c.Assert(err, IsNil)
+	c.Assert(s, Equals, "12345678901234567890.5")
+
+	i, err := cast.ToInt64E(decimal.RequireFromString("-9223372036854775808.9"))
+	c.Assert(err, IsNil)
+	c.Assert(i, Equals, int64(math.MinInt64))
+
+	_, err = cast.ToInt64E(decimal.RequireFromString("9223372036854775808"))
+	c.Assert(errors.Is(err, cast.ErrOverflow), IsTrue)
+
+	_, err = cast.ToUintE(decimal.RequireFromString("-0.5"))
+	c.Assert(errors.Is(err, cast.ErrNegative), IsTrue)
+
+	f, err := cast.ToFloat64E(decimal.RequireFromString("0.1"))
+	c.Assert(err, IsNil)
+	c.Assert(f, Equals, 0.1)
+
+	b, err := cast.ToBoolE(decimal.RequireFromString("0.000"))
+	c.Assert(err, IsNil)
+	c.Assert(b, IsFalse)
+
+	s, err = cast.ToStringE((*decimal.Decimal)(nil))
+	c.Assert(err, IsNil)
+	c.Assert(s, Equals, "")
+
+	v, err := cast.To[decimal.Decimal]("2.50")
+	c.Assert(err, IsNil)
+	c.Assert(v.String(), Equals, "2.5")
+}
+
+func TestStringGrammar(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		input  string
+		expect float64
+		kind   cast.ErrorKind
+	}{
+		// bool
+		{"t", 1, 0},
+		{"True", 1, 0},
+		{"FALSE", 0, 0},
+		{"yes", 0, cast.ErrSyntax},
+		// integer
+		{"0xff", 255, 0},
+		{"0XCAFE", 0xcafe, 0},
+		{"0o17", 15, 0},
+		{"0b101", 5, 0},
+		{"-0x10", -16, 0},
+		{"010", 8, 0},
+		{"0755", 493, 0},
+		{"-0_17", -15, 0},
+		{"00", 0, 0},
+		{"+8", 8, 0},
+		{"1_000", 1000, 0},
+		{"0x_1F", 31, 0},
+		{"0b1_0", 2, 0},
+		{"0x", 0, cast.ErrSyntax},
+		{"0xg", 0, cast.ErrSyntax},
+		{"08x", 0, cast.ErrSyntax},
+		{"08", 0, cast.ErrSyntax},
+		{"-019", 0, cast.ErrSyntax},
+		{"_1", 0, cast.ErrSyntax},
+		{"1_", 0, cast.ErrSyntax},
+		{"1__0", 0, cast.ErrSyntax},
+		{"0x__1", 0, cast.ErrSyntax},
+		// float
+		{"1e3", 1000, 0},
+		{"1e3f", 1000, 0},
+		{"1.5F", 1.5, 0},
+		{".5", 0.5, 0},
+		{"5.", 5, 0},
+		{"-2.5e-1", -0.25, 0},
+		{"0x1p-2", 0.25, 0},
+		{"0x1.8p1", 3, 0},
+		{"08.5", 8.5, 0},
+		{"010e1", 100, 0},
+		{"1_000.000_5", 1000.0005, 0},
+		{"1_.5", 0, cast.ErrSyntax},
+		{"1._5", 0, cast.ErrSyntax},
+		{"1e", 0, cast.ErrSyntax},
+		{"e3", 0, cast.ErrSyntax},
+		{"1.2.3", 0, cast.ErrSyntax},
+		{"0x1.8", 0, cast.ErrSyntax},
+		// special
+		{"inf", math.Inf(1), 0},
+		{"-Infinity", math.Inf(-1), 0},
+		{"NaN", math.NaN(), 0},
+		{"+nan", 0, cast.ErrSyntax},
+		{"infinit", 0, cast.ErrSyntax},
+		// rational
+		{"1/4", 0.25, 0},
+		{"-3/0x4", -0.75, 0},
+		{"0b1/0b10", 0.5, 0},
+		{"010/2", 4, 0},
+		{"1/09", 0, cast.ErrSyntax},
+		{"1/0", 0, cast.ErrNotFinite},
+		{"1/2/3", 0, cast.ErrSyntax},
+		{"1.5/2", 0, cast.ErrSyntax},
+		// complex
+		{"(3+4i)", 3, 0},
+		{"2i", 0, 0},
+		{"-1-2i", -1, 0},
+		{"(infi)", 0, 0},
+		{"(1+2i", 0, cast.ErrSyntax},
+		{"1+2", 0, cast.ErrSyntax},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %q", i, test.input)
+
+		v, err := cast.ToFloat64E(test.input)
+		if test.kind != 0 {
+			c.Assert(errors.Is(err, test.kind), IsTrue, errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+		if math.IsNaN(test.expect) {
+			c.Assert(math.IsNaN(v), IsTrue, errmsg)
+			continue
+		}
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+
+	n, err := cast.ToIntE("0xcafe")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0xcafe)
+
+	n, err = cast.ToIntE("0755")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0755)
+
+	n, err = cast.ToIntE("1_000")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1000)
+
+	n, err = cast.ToIntE("-8.9")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, -8)
+
+	_, err = cast.ToBigIntE("inf")
+	c.Assert(errors.Is(err, cast.ErrNotFinite), IsTrue)
+
+	r, err := cast.ToBigRatE("-0x10/0b110")
+	c.Assert(err, IsNil)
+	c.Assert(r.String(), Equals, "-8/3")
+
+	z, err := cast.ToComplex128E("-1-2i")
+	c.Assert(err, IsNil)
+	c.Assert(z, Equals, complex(-1, -2))
+
+	b, err := cast.ToBoolE("(0+1i)")
+	c.Assert(err, IsNil)
+	c.Assert(b, IsTrue)
+
+	b, err = cast.ToBoolE("0x0")
+	c.Assert(err, IsNil)
+	c.Assert(b, IsFalse)
+}
+
 func TestToComplex64(t *testing.T) {
 	tests := createDecimalTestSteps(testValues{
 		complex64(0 + 0i),
@@ -465,3 +700,186 @@ func TestToBoolE(t *testing.T) {
 		func(v any) any { return cast.ToBool(v) },
 	)
 }
+
+func TestToIntegerOverflow(t *testing.T) {
+	c := New(t)
+
+	huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+	tests := []struct {
+		name  string
+		tove  func(any) (any, error)
+		input any
+		iserr bool
+	}{
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, 127, false},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, -128, false},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, 300, true},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, -129, true},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, uint8(200), true},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, float64(128.5), true},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, "300", true},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, big.NewInt(1000), true},
+		{"int8", func(v any) (any, error) { return cast.ToInt8E(v) }, complex(1000, 0), true},
+		{"int16", func(v any) (any, error) { return cast.ToInt16E(v) }, 1 << 15, true},
+		{"int16", func(v any) (any, error) { return cast.ToInt16E(v) }, big.NewFloat(-40000), true},
+		{"int32", func(v any) (any, error) { return cast.ToInt32E(v) }, int64(1) << 31, true},
+		{"int32", func(v any) (any, error) { return cast.ToInt32E(v) }, big.NewRat(1<<40, 1), true},
+		{"int64", func(v any) (any, error) { return cast.ToInt64E(v) }, uint64(math.MaxUint64), true},
+		{"int64", func(v any) (any, error) { return cast.ToInt64E(v) }, float64(1e19), true},
+		{"int64", func(v any) (any, error) { return cast.ToInt64E(v) }, math.Inf(1), true},
+		{"int64", func(v any) (any, error) { return cast.ToInt64E(v) }, math.NaN(), true},
+		{"int64", func(v any) (any, error) { return cast.ToInt64E(v) }, huge, true},
+		{"int64", func(v any) (any, error) { return cast.ToInt64E(v) }, "100000000000000000000", true},
+		{"int64", func(v any) (any, error) { return cast.ToInt64E(v) }, "9223372036854775807", false},
+		{"uint8", func(v any) (any, error) { return cast.ToUint8E(v) }, 255, false},
+		{"uint8", func(v any) (any, error) { return cast.ToUint8E(v) }, 256, true},
+		{"uint8", func(v any) (any, error) { return cast.ToUint8E(v) }, "0x100", true},
+		{"uint16", func(v any) (any, error) { return cast.ToUint16E(v) }, int64(1 << 40), true},
+		{"uint32", func(v any) (any, error) { return cast.ToUint32E(v) }, float32(1e10), true},
+		{"uint64", func(v any) (any, error) { return cast.ToUint64E(v) }, huge, true},
+		{"uint64", func(v any) (any, error) { return cast.ToUint64E(v) }, float64(1e20), true},
+		{"uint64", func(v any) (any, error) { return cast.ToUint64E(v) }, "18446744073709551615", false},
+		{"uint64", func(v any) (any, error) { return cast.ToUint64E(v) }, "18446744073709551616", true},
+		{"float32", func(v any) (any, error) { return cast.ToFloat32E(v) }, float64(1e300), true},
+		{"float32", func(v any) (any, error) { return cast.ToFloat32E(v) }, "1e300", true},
+		{"float32", func(v any) (any, error) { return cast.ToFloat32E(v) }, math.Inf(-1), false},
+		{"float64", func(v any) (any, error) { return cast.ToFloat64E(v) }, "1e400", true},
+		{"complex64", func(v any) (any, error) { return cast.ToComplex64E(v) }, complex(1, 1e300), true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, target = %s, input = %#v", i, test.name, test.input)
+
+		_, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+	}
+}
+
+func TestToUnsignedNegative(t *testing.T) {
+	c := New(t)
+
+	halfUp, err := cast.New(cast.RoundHalfUp)
+	c.Assert(err, IsNil)
+
+	tests := []struct {
+		toue   func(any) (uint, error)
+		input  any
+		expect uint
+		iserr  bool
+	}{
+		{cast.ToUintE, -0.5, 0, true},
+		{cast.ToUintE, float32(-0.5), 0, true},
+		{cast.ToUintE, "-0.5", 0, true},
+		{cast.ToUintE, "-1/2", 0, true},
+		{cast.ToUintE, "-1e-9", 0, true},
+		{cast.ToUintE, big.NewRat(-1, 2), 0, true},
+		{cast.ToUintE, big.NewFloat(-0.5), 0, true},
+		{cast.ToUintE, decimal.RequireFromString("-0.5"), 0, true},
+		{cast.ToUintE, complex(-0.5, 0), 0, true},
+		{cast.ToUintE, "-0", 0, false},
+		{cast.ToUintE, math.Copysign(0, -1), 0, false},
+		{cast.ToUintE, "0.5", 0, false},
+		{halfUp.ToUintE, -0.4, 0, true},
+		{halfUp.ToUintE, "-0.4", 0, true},
+		{halfUp.ToUintE, big.NewRat(-2, 5), 0, true},
+		{halfUp.ToUintE, decimal.RequireFromString("-0.4"), 0, true},
+		{halfUp.ToUintE, 0.5, 1, false},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.toue(test.input)
+		if test.iserr {
+			c.Assert(errors.Is(err, cast.ErrNegative), IsTrue, errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+}
+
+func TestBigPrecision(t *testing.T) {
+	c := New(t)
+
+	huge, _ := new(big.Int).SetString("12345678901234567890123", 10)
+	hugeRat := new(big.Rat).SetInt(huge)
+
+	n, err := cast.ToBigIntE(hugeRat)
+	c.Assert(err, IsNil)
+	c.Assert(n.String(), Equals, huge.String())
+
+	n, err = cast.ToBigIntE("12345678901234567890123/1")
+	c.Assert(err, IsNil)
+	c.Assert(n.String(), Equals, huge.String())
+
+	n, err = cast.ToBigIntE(big.NewRat(-7, 2))
+	c.Assert(err, IsNil)
+	c.Assert(n.String(), Equals, "-3")
+
+	f, err := cast.ToBigFloatE(hugeRat)
+	c.Assert(err, IsNil)
+	c.Assert(f.Text('f', 0), Equals, huge.String())
+
+	f, err = cast.ToBigFloatE("12345678901234567890123/1")
+	c.Assert(err, IsNil)
+	c.Assert(f.Text('f', 0), Equals, huge.String())
+
+	f, err = cast.ToBigFloatE("12345678901234567890123.5")
+	c.Assert(err, IsNil)
+	c.Assert(f.Text('f', 1), Equals, "12345678901234567890123.5")
+
+	bf, _ := new(big.Float).SetPrec(200).SetString("12345678901234567890123.25")
+	r, err := cast.ToBigRatE(bf)
+	c.Assert(err, IsNil)
+	c.Assert(r.String(), Equals, "49382715604938271560493/4")
+
+	i, err := cast.ToInt64E(big.NewRat(math.MaxInt64, 1))
+	c.Assert(err, IsNil)
+	c.Assert(i, Equals, int64(math.MaxInt64))
+
+	i, err = cast.ToInt64E(big.NewRat(-7, 2))
+	c.Assert(err, IsNil)
+	c.Assert(i, Equals, int64(-3))
+
+	u, err := cast.ToUint64E(new(big.Rat).SetUint64(math.MaxUint64))
+	c.Assert(err, IsNil)
+	c.Assert(u, Equals, uint64(math.MaxUint64))
+
+	_, err = cast.ToUintE(big.NewRat(-1, 2))
+	c.Assert(err, IsNotNil)
+
+	// 1 + 2^-53 + 2^-70 rounds up to 1 + 2^-52; rounding through a 64-bit
+	// intermediate would tie and round down to 1.
+	f64, err := cast.ToFloat64E("1.0000000000000001110231494954629083427022351315827108919620513916015625")
+	c.Assert(err, IsNil)
+	c.Assert(f64, Equals, math.Nextafter(1, 2))
+
+	b, err := cast.ToBoolE("1/1" + strings.Repeat("0", 400))
+	c.Assert(err, IsNil)
+	c.Assert(b, IsTrue)
+}
+
+func BenchmarkToIntE(b *testing.B) {
+	for _, input := range []any{8, "8", 8.5, myInt(8)} {
+		b.Run(fmt.Sprintf("%T", input), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = cast.ToIntE(input)
+			}
+		})
+	}
+}
+
+func BenchmarkToFloat64E(b *testing.B) {
+	for _, input := range []any{8, "8.5", big.NewRat(17, 2)} {
+		b.Run(fmt.Sprintf("%T", input), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = cast.ToFloat64E(input)
+			}
+		})
+	}
+}
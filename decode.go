@@ -0,0 +1,305 @@
+package cast
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// TagName is an option of Decode naming the struct tag that holds the key of
+// a field. It is "cast" by default.
+type TagName string
+
+// DecodeFlag is an option of Decode that changes how the input is matched to
+// the fields of a struct.
+type DecodeFlag int
+
+const (
+	// DisallowUnknownFields makes Decode fail on a key of the input that
+	// matches no field of the struct.
+	DisallowUnknownFields DecodeFlag = iota + 1
+)
+
+const defaultTagName = "cast"
+
+var errUnknownField = errors.New("unknown field")
+
+// Decode casts input into the value pointed to by out, which must be a
+// non-nil pointer.
+//
+// A struct is decoded from a map of any type, or from JSON text, as by
+// ToStringMapE. Each exported field takes the entry whose key is the name in
+// the field's `cast:"name"` tag, or else the field name, matched exactly or,
+// failing that, case-insensitively. A field tagged `cast:"-"` is skipped, and
+// the fields of an embedded struct, or of an exported embedded pointer to a
+// struct, without a tag are decoded as fields of the outer struct, the pointer
+// being allocated when one of them has an entry. Fields without an entry keep
+// their values.
+//
+// Pointers are allocated as needed, and slices, maps and the fields of nested
+// structs are decoded element by element, unless a Conversion to the struct
+// type accepts the entry. Any other value is cast with To, so the rules of the
+// ToXxxE functions apply throughout. A failing field is reported by a
+// *FieldError in the error's chain.
+//
+// The options are a TagName, DisallowUnknownFields and those of New, as for
+// To.
+func Decode(input any, out any, args ...any) error {
+	return std.Decode(input, out, args...)
+}
+
+// Decode casts input into the value pointed to by out like the Decode
+// function, with the settings of c.
+func (c *Caster) Decode(input any, out any, args ...any) error {
+	d, err := c.parseDecodeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("cast: Decode requires a non-nil pointer, got %T", out)
+	}
+	return d.decode(input, v.Elem())
+}
+
+type decoder struct {
+	*Caster
+	tagName         string
+	disallowUnknown bool
+}
+
+// parseDecodeArgs collects the options accepted by Decode, those of New
+// applying to a copy of c.
+func (c *Caster) parseDecodeArgs(args []any) (*decoder, error) {
+	d := &decoder{tagName: defaultTagName}
+	var rest []any
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case TagName:
+			d.tagName = string(v)
+		case DecodeFlag:
+			if v != DisallowUnknownFields {
+				return nil, unsupportedOption(arg)
+			}
+			d.disallowUnknown = true
+		default:
+			rest = append(rest, arg)
+		}
+	}
+
+	var err error
+	d.Caster, err = c.with(rest)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// decode casts a into v, which must be settable.
+func (d *decoder) decode(a any, v reflect.Value) error {
+	t := v.Type()
+	if _, ok := casters[t]; ok {
+		return d.set(a, v)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if isNil(a) {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return d.decode(a, v.Elem())
+	case reflect.Struct:
+		if _, ok := d.Lookup(reflect.TypeOf(a), t); ok || isNullType(t) {
+			return d.set(a, v)
+		}
+		return d.decodeStruct(a, v)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return d.set(a, v)
+		}
+		s, err := toSlice(a, t, d.decodeNew(t.Elem()))
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(s))
+		return nil
+	case reflect.Map:
+		m, err := toMap(a, t,
+			func(k any) (any, error) { return d.toType(k, t.Key()) },
+			d.decodeNew(t.Elem()))
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(m))
+		return nil
+	case reflect.Interface:
+		if a == nil {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if reflect.TypeOf(a).AssignableTo(t) {
+			v.Set(reflect.ValueOf(a))
+			return nil
+		}
+		return newCastError(a, t.String(), ErrUnsupported, nil)
+	default:
+		return d.set(a, v)
+	}
+}
+
+// decodeNew returns a function decoding its argument into a new value of type
+// t, for the elements of slices and maps.
+func (d *decoder) decodeNew(t reflect.Type) func(any) (any, error) {
+	return func(a any) (any, error) {
+		v := reflect.New(t).Elem()
+		err := d.decode(a, v)
+		return v.Interface(), err
+	}
+}
+
+// set casts a to the type of v with To and stores the result in v.
+func (d *decoder) set(a any, v reflect.Value) error {
+	x, err := d.toType(a, v.Type())
+	if err != nil {
+		return err
+	}
+	v.Set(valueOf(x, v.Type()))
+	return nil
+}
+
+func (d *decoder) decodeStruct(a any, v reflect.Value) error {
+	t := v.Type()
+	if a == nil {
+		return nil
+	}
+	if reflect.TypeOf(a) == t {
+		v.Set(reflect.ValueOf(a))
+		return nil
+	}
+
+	m, err := castTo[map[string]any](d.Caster, a)
+	if err != nil {
+		return wrapError(a, t.String(), err)
+	}
+
+	used := make(map[string]bool, len(m))
+	for _, f := range d.fields(t) {
+		key, ok := lookupKey(m, f.name)
+		if !ok {
+			continue
+		}
+		used[key] = true
+		if err := d.decode(m[key], fieldByIndex(v, f.index)); err != nil {
+			return newCastError(a, t.String(), errorKind(err), &FieldError{Field: f.name, Err: err})
+		}
+	}
+
+	if d.disallowUnknown {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			if !used[k] {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) > 0 {
+			sort.Strings(keys)
+			return newCastError(a, t.String(), ErrUnsupported, &KeyError{Key: keys[0], Err: errUnknownField})
+		}
+	}
+	return nil
+}
+
+// lookupKey returns the key of m matching name exactly or, failing that,
+// case-insensitively.
+func lookupKey(m map[string]any, name string) (string, bool) {
+	if _, ok := m[name]; ok {
+		return name, true
+	}
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+type field struct {
+	name  string
+	index []int
+}
+
+// fields returns the fields of the struct type t that Decode sets, including
+// those of embedded structs and pointers to structs without a tag.
+func (d *decoder) fields(t reflect.Type) []field {
+	return d.embeddedFields(t, map[reflect.Type]bool{t: true})
+}
+
+// embeddedFields returns the fields of t like fields, skipping the embedded
+// structs in outer, which holds t and the structs embedding it.
+func (d *decoder) embeddedFields(t reflect.Type, outer map[reflect.Type]bool) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get(d.tagName), ",")
+		if name == "-" {
+			continue
+		}
+		if et, ok := embeddedStruct(sf); ok && name == "" {
+			if outer[et] {
+				continue
+			}
+			outer[et] = true
+			for _, f := range d.embeddedFields(et, outer) {
+				fields = append(fields, field{name: f.name, index: append([]int{i}, f.index...)})
+			}
+			delete(outer, et)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{name: name, index: []int{i}})
+	}
+	return fields
+}
+
+// embeddedStruct returns the struct type of the embedded field sf, which is
+// either a struct or, when exported, a pointer to one. Unexported pointers are
+// left out as they cannot be allocated.
+func embeddedStruct(sf reflect.StructField) (reflect.Type, bool) {
+	if !sf.Anonymous {
+		return nil, false
+	}
+	switch t := sf.Type; {
+	case t.Kind() == reflect.Struct:
+		return t, true
+	case t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && sf.IsExported():
+		return t.Elem(), true
+	}
+	return nil, false
+}
+
+// fieldByIndex returns the field of the struct v at index like
+// reflect.Value.FieldByIndex, allocating the nil embedded pointers on its way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,167 @@
+package cast_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+type decodeBase struct {
+	ID int `cast:"id"`
+}
+
+type decodeServer struct {
+	Host string `cast:"host"`
+	Port uint16 `cast:"port"`
+}
+
+type decodeConfig struct {
+	decodeBase
+	Name     string                  `cast:"name"`
+	Enabled  bool                    `cast:"enabled"`
+	Ratio    *big.Rat                `cast:"ratio"`
+	Timeout  time.Duration           `cast:"timeout"`
+	Server   decodeServer            `cast:"server"`
+	Backup   *decodeServer           `cast:"backup"`
+	Replicas []decodeServer          `cast:"replicas"`
+	Tags     []string                `cast:"tags"`
+	Limits   map[string]int          `cast:"limits"`
+	Zones    map[string]decodeServer `cast:"zones"`
+	Extra    any                     `cast:"extra"`
+	Level    myInt
+	Ignored  string `cast:"-"`
+	private  string
+}
+
+func TestDecode(t *testing.T) {
+	c := New(t)
+
+	input := map[any]any{
+		"id":       "7",
+		"name":     "api",
+		"enabled":  "t",
+		"ratio":    "1/3",
+		"timeout":  "1m30s",
+		"server":   map[any]any{"host": "localhost", "port": "0x1f90"},
+		"backup":   map[string]any{"host": "backup", "port": 8081.0},
+		"replicas": []any{map[string]any{"host": "r1", "port": 1}, map[any]any{"host": "r2"}},
+		"tags":     "a, b",
+		"limits":   map[string]any{"cpu": "2", "mem": 1024},
+		"zones":    `{"eu": {"host": "eu1", "port": 443}}`,
+		"extra":    []int{1},
+		"level":    "3",
+		"Ignored":  "x",
+		"private":  "x",
+	}
+
+	out := decodeConfig{Ignored: "kept"}
+	err := cast.Decode(input, &out)
+	c.Assert(err, IsNil)
+
+	c.Assert(out.ID, Equals, 7)
+	c.Assert(out.Name, Equals, "api")
+	c.Assert(out.Enabled, IsTrue)
+	c.Assert(out.Ratio.String(), Equals, "1/3")
+	c.Assert(out.Timeout, Equals, 90*time.Second)
+	c.Assert(out.Server, Equals, decodeServer{"localhost", 8080})
+	c.Assert(*out.Backup, Equals, decodeServer{"backup", 8081})
+	c.Assert(out.Replicas, DeepEquals, []decodeServer{{"r1", 1}, {"r2", 0}})
+	c.Assert(out.Tags, DeepEquals, []string{"a", "b"})
+	c.Assert(out.Limits, DeepEquals, map[string]int{"cpu": 2, "mem": 1024})
+	c.Assert(out.Zones, DeepEquals, map[string]decodeServer{"eu": {"eu1", 443}})
+	c.Assert(out.Extra, DeepEquals, []int{1})
+	c.Assert(out.Level, Equals, myInt(3))
+	c.Assert(out.Ignored, Equals, "kept")
+	c.Assert(out.private, Equals, "")
+}
+
+type DecodeMeta struct {
+	Version int `cast:"version"`
+}
+
+type decodeEmbedded struct {
+	*DecodeMeta
+	*decodeBase
+	*decodeEmbedded
+	Name  string   `cast:"name"`
+	Count *int     `cast:"count"`
+	Ratio *big.Rat `cast:"ratio"`
+}
+
+func TestDecodeEmbeddedPointer(t *testing.T) {
+	c := New(t)
+
+	var out decodeEmbedded
+	err := cast.Decode(map[string]any{"version": "2", "id": 1, "name": "n"}, &out)
+	c.Assert(err, IsNil)
+	c.Assert(out.DecodeMeta, DeepEquals, &DecodeMeta{Version: 2})
+	c.Assert(out.decodeBase, IsNil)
+	c.Assert(out.decodeEmbedded, IsNil)
+	c.Assert(out.Name, Equals, "n")
+
+	out = decodeEmbedded{}
+	err = cast.Decode(map[string]any{"name": "n"}, &out)
+	c.Assert(err, IsNil)
+	c.Assert(out.DecodeMeta, IsNil)
+
+	one := 1
+	out = decodeEmbedded{Count: &one, Ratio: big.NewRat(1, 2)}
+	err = cast.Decode(map[string]any{"count": (*int)(nil), "ratio": (*big.Rat)(nil)}, &out)
+	c.Assert(err, IsNil)
+	c.Assert(out.Count, IsNil)
+	c.Assert(out.Ratio.Sign(), Equals, 0)
+}
+
+func TestDecodeOptions(t *testing.T) {
+	c := New(t)
+
+	var out struct {
+		Host string `yaml:"hostname"`
+		Port int
+	}
+	err := cast.Decode(`{"hostname": "h", "port": "80"}`, &out, cast.TagName("yaml"))
+	c.Assert(err, IsNil)
+	c.Assert(out.Host, Equals, "h")
+	c.Assert(out.Port, Equals, 80)
+
+	err = cast.Decode(map[string]any{"host": "h", "other": 1}, &decodeServer{}, cast.DisallowUnknownFields)
+	var ke *cast.KeyError
+	c.Assert(errors.As(err, &ke), IsTrue)
+	c.Assert(ke.Key, Equals, any("other"))
+	c.Assert(errors.Is(err, cast.ErrUnsupported), IsTrue)
+
+	err = cast.Decode(map[string]any{}, &out, "nope")
+	c.Assert(err, ErrorMatches, `unsupported option "nope" of type string`)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	c := New(t)
+
+	var out decodeConfig
+	err := cast.Decode(map[string]any{"server": map[string]any{"port": "70000"}}, &out)
+	var fe *cast.FieldError
+	c.Assert(errors.As(err, &fe), IsTrue)
+	c.Assert(fe.Field, Equals, "server")
+	c.Assert(errors.Is(err, cast.ErrOverflow), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast .* to cast_test.decodeConfig: field server: unable to cast .* to cast_test.decodeServer: field port: unable to cast "70000" of type string to uint16: value out of range`)
+
+	err = cast.Decode(map[string]any{"replicas": []any{map[string]any{}, map[string]any{"port": "x"}}}, &out)
+	var ie *cast.IndexError
+	c.Assert(errors.As(err, &ie), IsTrue)
+	c.Assert(ie.Index, Equals, 1)
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+
+	err = cast.Decode(8, &out)
+	c.Assert(errors.Is(err, cast.ErrUnsupported), IsTrue)
+
+	err = cast.Decode(map[string]any{}, out)
+	c.Assert(err, ErrorMatches, `cast: Decode requires a non-nil pointer, got cast_test.decodeConfig`)
+
+	var n int
+	c.Assert(cast.Decode("8", &n), IsNil)
+	c.Assert(n, Equals, 8)
+}
@@ -0,0 +1,179 @@
+package cast
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrorKind classifies why a cast failed. ErrorKind implements error, so a
+// kind can be used as the target of errors.Is:
+//
+//	if errors.Is(err, cast.ErrOverflow) {
+//		...
+//	}
+type ErrorKind int
+
+const (
+	// ErrUnsupported reports that the source type cannot be cast to the
+	// target type.
+	ErrUnsupported ErrorKind = iota + 1
+	// ErrSyntax reports that a textual value could not be parsed.
+	ErrSyntax
+	// ErrOverflow reports that the value does not fit in the target type.
+	ErrOverflow
+	// ErrNegative reports that a negative value was cast to an unsigned
+	// target type.
+	ErrNegative
+	// ErrNotFinite reports that a NaN or an infinity was cast to a target
+	// type that cannot represent it.
+	ErrNotFinite
+	// ErrInexact reports that a cast in Strict mode would not preserve the
+	// value exactly.
+	ErrInexact
+	// ErrNil reports that nil was cast by a Caster with the NilError mode.
+	ErrNil
+)
+
+var errorKindText = map[ErrorKind]string{
+	ErrUnsupported: "unsupported type",
+	ErrSyntax:      "invalid syntax",
+	ErrOverflow:    "value out of range",
+	ErrNegative:    "negative value",
+	ErrNotFinite:   "value is NaN or infinite",
+	ErrInexact:     "value not exactly representable",
+	ErrNil:         "nil value",
+}
+
+// Error returns a short description of the kind.
+func (k ErrorKind) Error() string {
+	if s, ok := errorKindText[k]; ok {
+		return s
+	}
+	return "ErrorKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// CastError records a failed cast and the reason it failed.
+type CastError struct {
+	Value any       // the value being cast
+	From  string    // the type of Value, as formatted by %T
+	To    string    // the target type, e.g. "int8" or "*big.Int"
+	Kind  ErrorKind // the reason the cast failed
+	Err   error     // the underlying parse error, if any
+}
+
+func (e *CastError) Error() string {
+	msg := fmt.Sprintf("unable to cast %#v of type %s to %s", e.Value, e.From, e.To)
+	switch {
+	case e.Err != nil:
+		return msg + ": " + e.Err.Error()
+	case e.Kind != ErrUnsupported:
+		return msg + ": " + e.Kind.Error()
+	default:
+		return msg
+	}
+}
+
+// Unwrap returns the underlying error.
+func (e *CastError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the ErrorKind of e.
+func (e *CastError) Is(target error) bool {
+	k, ok := target.(ErrorKind)
+	return ok && k == e.Kind
+}
+
+// IndexError records the element of a slice or array that failed to cast. It
+// is the underlying error of the *CastError returned by the slice casts.
+type IndexError struct {
+	Index int   // the position of the element
+	Err   error // the error casting the element
+}
+
+func (e *IndexError) Error() string {
+	return "index " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error casting the element.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// KeyError records the entry of a map that failed to cast. It is the
+// underlying error of the *CastError returned by the map casts.
+type KeyError struct {
+	Key any   // the key of the entry, as found in the source map
+	Err error // the error casting the key or the value
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("key %#v: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the error casting the entry.
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError records the struct field that failed to decode. It is the
+// underlying error of the *CastError returned by Decode.
+type FieldError struct {
+	Field string // the name of the field, as read from its tag
+	Err   error  // the error decoding the field
+}
+
+func (e *FieldError) Error() string {
+	return "field " + e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error decoding the field.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func newCastError(a any, to string, kind ErrorKind, err error) *CastError {
+	return &CastError{
+		Value: a,
+		From:  fmt.Sprintf("%T", a),
+		To:    to,
+		Kind:  kind,
+		Err:   err,
+	}
+}
+
+// errorKind returns the kind of the *CastError in err's chain, or ErrSyntax
+// when there is none.
+func errorKind(err error) ErrorKind {
+	var ce *CastError
+	if errors.As(err, &ce) {
+		return ce.Kind
+	}
+	return ErrSyntax
+}
+
+// wrapError converts an error returned while casting a into a *CastError
+// reporting a as its value. Errors from strconv are kept as the underlying
+// error; anything that does not carry a kind is a syntax error. A nil error is
+// returned unchanged.
+func wrapError(a any, to string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		ce   *CastError
+		kind ErrorKind
+	)
+	switch {
+	case errors.As(err, &ce):
+		return newCastError(a, to, ce.Kind, ce.Err)
+	case errors.As(err, &kind):
+		return newCastError(a, to, kind, nil)
+	case errors.Is(err, strconv.ErrRange):
+		return newCastError(a, to, ErrOverflow, err)
+	default:
+		return newCastError(a, to, ErrSyntax, err)
+	}
+}
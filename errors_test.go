@@ -0,0 +1,72 @@
+package cast_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+func TestCastError(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove  func(any) (any, error)
+		input any
+		to    string
+		kind  cast.ErrorKind
+	}{
+		{func(v any) (any, error) { return cast.ToInt8E(v) }, 300, "int8", cast.ErrOverflow},
+		{func(v any) (any, error) { return cast.ToInt8E(v) }, "300", "int8", cast.ErrOverflow},
+		{func(v any) (any, error) { return cast.ToUint16E(v) }, int64(1 << 40), "uint16", cast.ErrOverflow},
+		{func(v any) (any, error) { return cast.ToUintE(v) }, -1, "uint", cast.ErrNegative},
+		{func(v any) (any, error) { return cast.ToUint64E(v) }, big.NewInt(-1), "uint64", cast.ErrNegative},
+		{func(v any) (any, error) { return cast.ToUint8E(v) }, "-8", "uint8", cast.ErrNegative},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, "test", "int", cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, []byte("test"), "bool", cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToBigIntE(v) }, "8x", "*big.Int", cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, math.NaN(), "int", cast.ErrNotFinite},
+		{func(v any) (any, error) { return cast.ToBigRatE(v) }, math.Inf(1), "*big.Rat", cast.ErrNotFinite},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, testing.T{}, "float64", cast.ErrUnsupported},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, testing.T{}, "string", cast.ErrUnsupported},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		_, err := test.tove(test.input)
+		c.Assert(err, IsNotNil, errmsg)
+		c.Assert(errors.Is(err, test.kind), IsTrue, errmsg)
+
+		var ce *cast.CastError
+		c.Assert(errors.As(err, &ce), IsTrue, errmsg)
+		c.Assert(ce.Kind, Equals, test.kind, errmsg)
+		c.Assert(ce.To, Equals, test.to, errmsg)
+		c.Assert(ce.From, Equals, fmt.Sprintf("%T", test.input), errmsg)
+	}
+}
+
+func TestCastErrorUnwrap(t *testing.T) {
+	c := New(t)
+
+	_, err := cast.ToBoolE("tru")
+	var ne *strconv.NumError
+	c.Assert(errors.As(err, &ne), IsTrue)
+	c.Assert(errors.Is(err, strconv.ErrSyntax), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast "tru" of type string to bool: strconv.ParseBool: parsing "tru": invalid syntax`)
+
+	_, err = cast.ToInt8E(300)
+	var ce *cast.CastError
+	c.Assert(errors.As(err, &ce), IsTrue)
+	c.Assert(ce.Value, Equals, any(300))
+	c.Assert(ce.Err, IsNil)
+	c.Assert(err, ErrorMatches, `unable to cast 300 of type int to int8: value out of range`)
+
+	_, err = cast.ToIntE(struct{}{})
+	c.Assert(err, ErrorMatches, `unable to cast struct {}{} of type struct {} to int`)
+}
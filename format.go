@@ -0,0 +1,202 @@
+package cast
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// NumberFormat is an option of New formatting the numbers cast to text, of
+// any integer or float type, *big.Int, *big.Float, *big.Rat and
+// decimal.Decimal, for presentation. It takes precedence over a FloatFormat.
+//
+// The numbers are written from their decimal value: the shortest one that
+// rounds back to a float, and a *big.Rat with no finite decimal form rounded
+// to DivisionPrecision digits, as by ToDecimalE. NaN and the infinities are
+// written as by strconv.FormatFloat.
+type NumberFormat struct {
+	// Locale supplies the decimal mark and the grouping of the digits. The
+	// zero Locale writes "." and groups three digits with ",".
+	Locale Locale
+	// Group writes the integer digits in groups.
+	Group bool
+	// Fixed rounds the numbers half away from zero to Places digits after
+	// the decimal mark, which are all written. Otherwise all the digits
+	// needed are written.
+	Fixed  bool
+	Places int
+	// MinIntegerDigits pads the integer digits with leading zeros.
+	MinIntegerDigits int
+	// PlusSign writes "+" before the numbers that are not negative.
+	PlusSign bool
+	// Notation selects plain, scientific or engineering notation.
+	Notation Notation
+}
+
+// Notation is the notation of the numbers written with a NumberFormat.
+type Notation int
+
+const (
+	// PlainNotation writes numbers without an exponent, such as "1234.5".
+	PlainNotation Notation = iota
+	// ScientificNotation writes numbers with one integer digit and an
+	// exponent, such as "1.2345e+03".
+	ScientificNotation
+	// EngineeringNotation writes numbers with one to three integer digits
+	// and an exponent multiple of three, such as "1.2345e+03" or "12.5e-06".
+	EngineeringNotation
+)
+
+// defaultNumberLocale is the Locale of a NumberFormat with the zero Locale.
+var defaultNumberLocale = Locale{Decimal: ".", Group: ",", Grouping: []int{3}}
+
+// isZero reports whether l is the zero Locale.
+func (l Locale) isZero() bool {
+	return l.Tag == "" && l.Decimal == "" && l.Group == "" && l.Grouping == nil
+}
+
+// isZero reports whether f is the zero NumberFormat.
+func (f NumberFormat) isZero() bool {
+	return f.Locale.isZero() && !f.Group && !f.Fixed && f.Places == 0 &&
+		f.MinIntegerDigits == 0 && !f.PlusSign && f.Notation == PlainNotation
+}
+
+// valid reports whether f can be used to format numbers.
+func (f NumberFormat) valid() bool {
+	return (f.Locale.isZero() || f.Locale.valid()) &&
+		f.Places >= 0 && f.MinIntegerDigits >= 0 &&
+		f.Notation >= PlainNotation && f.Notation <= EngineeringNotation
+}
+
+// formatNumber formats a with the NumberFormat of c, reporting whether it is
+// a number the NumberFormat applies to.
+func (c *Caster) formatNumber(a any) (string, bool) {
+	if c.numbers == nil {
+		return "", false
+	}
+
+	switch v := a.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		*big.Int, *big.Rat, decimal.Decimal, *decimal.Decimal:
+	case float32:
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			return strconv.FormatFloat(float64(v), 'g', -1, 32), true
+		}
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return strconv.FormatFloat(v, 'g', -1, 64), true
+		}
+	case *big.Float:
+		if v != nil && v.IsInf() {
+			return v.String(), true
+		}
+	default:
+		return "", false
+	}
+
+	// Nil pointers are written as the casts do without a NumberFormat.
+	d, err := c.toDecimalE(a)
+	if err != nil {
+		return "", false
+	}
+	return c.numbers.format(d), true
+}
+
+// format writes d as described by f.
+func (f *NumberFormat) format(d decimal.Decimal) string {
+	m, exp := d, 0
+	switch f.Notation {
+	case ScientificNotation:
+		m, exp = f.scale(d, 1)
+	case EngineeringNotation:
+		m, exp = f.scale(d, 3)
+	default:
+		if f.Fixed {
+			m = d.Round(int32(f.Places))
+		}
+	}
+
+	text := m.Abs().String()
+	if f.Fixed {
+		text = m.Abs().StringFixed(int32(f.Places))
+	}
+	integer, fraction, _ := strings.Cut(text, ".")
+	if n := f.MinIntegerDigits - len(integer); n > 0 {
+		integer = strings.Repeat("0", n) + integer
+	}
+	if f.Group {
+		integer = f.Locale.group(integer)
+	}
+
+	var b strings.Builder
+	switch {
+	case m.Sign() < 0:
+		b.WriteByte('-')
+	case f.PlusSign:
+		b.WriteByte('+')
+	}
+	b.WriteString(integer)
+	if fraction != "" {
+		b.WriteString(f.Locale.Decimal)
+		b.WriteString(fraction)
+	}
+	if f.Notation != PlainNotation {
+		fmt.Fprintf(&b, "e%+03d", exp)
+	}
+	return b.String()
+}
+
+// scale returns the mantissa and exponent of d, the exponent being a multiple
+// of step and the mantissa having at most step integer digits. The mantissa
+// is rounded when f is Fixed.
+func (f *NumberFormat) scale(d decimal.Decimal, step int) (decimal.Decimal, int) {
+	if d.IsZero() {
+		return d, 0
+	}
+
+	digits := len(d.Abs().Coefficient().String())
+	exp := floorMultiple(digits-1+int(d.Exponent()), step)
+	m := f.round(d.Shift(int32(-exp)))
+	if limit := decimal.New(1, int32(step)); m.Abs().GreaterThanOrEqual(limit) {
+		// Rounding carried into a new integer digit.
+		exp += step
+		m = f.round(d.Shift(int32(-exp)))
+	}
+	return m, exp
+}
+
+func (f *NumberFormat) round(d decimal.Decimal) decimal.Decimal {
+	if f.Fixed {
+		return d.Round(int32(f.Places))
+	}
+	return d
+}
+
+// floorMultiple returns the greatest multiple of step not greater than n.
+func floorMultiple(n, step int) int {
+	if n < 0 {
+		return -((-n + step - 1) / step * step)
+	}
+	return n / step * step
+}
+
+// group writes the separator of l between the groups of the digits.
+func (l Locale) group(digits string) string {
+	var groups []string
+	for i := 0; len(digits) > 0; i++ {
+		n := l.groupSize(i)
+		if n > len(digits) {
+			n = len(digits)
+		}
+		groups = append(groups, digits[len(digits)-n:])
+		digits = digits[:len(digits)-n]
+	}
+	for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+		groups[i], groups[j] = groups[j], groups[i]
+	}
+	return strings.Join(groups, l.Group)
+}
@@ -0,0 +1,104 @@
+package cast_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
+)
+
+func TestNumberFormat(t *testing.T) {
+	c := New(t)
+
+	de, _ := cast.LookupLocale("de-DE")
+	in, _ := cast.LookupLocale("en-IN")
+	tests := []struct {
+		format cast.NumberFormat
+		input  any
+		expect string
+	}{
+		{cast.NumberFormat{Group: true}, 1234567, "1,234,567"},
+		{cast.NumberFormat{Group: true}, -1234567.125, "-1,234,567.125"},
+		{cast.NumberFormat{Group: true}, 123, "123"},
+		{cast.NumberFormat{Group: true}, uint64(math.MaxUint64), "18,446,744,073,709,551,615"},
+		{cast.NumberFormat{Locale: de, Group: true}, 1234567.5, "1.234.567,5"},
+		{cast.NumberFormat{Locale: de}, 1234567.5, "1234567,5"},
+		{cast.NumberFormat{Locale: in, Group: true}, int64(1234567), "12,34,567"},
+		{cast.NumberFormat{Fixed: true, Places: 2}, 1.005, "1.01"},
+		{cast.NumberFormat{Fixed: true, Places: 2}, 3, "3.00"},
+		{cast.NumberFormat{Fixed: true, Places: 2}, -0.001, "0.00"},
+		{cast.NumberFormat{Fixed: true}, 2.5, "3"},
+		{cast.NumberFormat{Fixed: true, Places: 3}, big.NewRat(1, 3), "0.333"},
+		{cast.NumberFormat{MinIntegerDigits: 3}, 7, "007"},
+		{cast.NumberFormat{MinIntegerDigits: 2}, 0.5, "00.5"},
+		{cast.NumberFormat{PlusSign: true}, 5, "+5"},
+		{cast.NumberFormat{PlusSign: true}, 0, "+0"},
+		{cast.NumberFormat{PlusSign: true}, -5, "-5"},
+		{cast.NumberFormat{Notation: cast.ScientificNotation}, 1234.5, "1.2345e+03"},
+		{cast.NumberFormat{Notation: cast.ScientificNotation}, float32(0.00012), "1.2e-04"},
+		{cast.NumberFormat{Notation: cast.ScientificNotation}, 0, "0e+00"},
+		{cast.NumberFormat{Notation: cast.ScientificNotation, Fixed: true, Places: 2}, 9999, "1.00e+04"},
+		{cast.NumberFormat{Notation: cast.ScientificNotation, Fixed: true, Places: 2}, -123456, "-1.23e+05"},
+		{cast.NumberFormat{Notation: cast.ScientificNotation, Locale: de}, 1234.5, "1,2345e+03"},
+		{cast.NumberFormat{Notation: cast.EngineeringNotation}, 12345, "12.345e+03"},
+		{cast.NumberFormat{Notation: cast.EngineeringNotation}, 0.0000125, "12.5e-06"},
+		{cast.NumberFormat{Notation: cast.EngineeringNotation}, -0.5, "-500e-03"},
+		{cast.NumberFormat{Notation: cast.EngineeringNotation, Fixed: true, Places: 1}, 999.96, "1.0e+03"},
+		{cast.NumberFormat{Group: true, Fixed: true, Places: 2}, new(big.Int).Lsh(big.NewInt(1), 70), "1,180,591,620,717,411,303,424.00"},
+		{cast.NumberFormat{Group: true}, big.NewFloat(12345.5), "12,345.5"},
+		{cast.NumberFormat{Group: true}, decimal.RequireFromString("-98765.4321"), "-98,765.4321"},
+		{cast.NumberFormat{Group: true, PlusSign: true}, math.Inf(1), "+Inf"},
+		{cast.NumberFormat{Group: true}, math.NaN(), "NaN"},
+		{cast.NumberFormat{Group: true}, big.NewFloat(math.Inf(-1)), "-Inf"},
+		// Values other than numbers keep their text.
+		{cast.NumberFormat{Group: true}, "1234567", "1234567"},
+		{cast.NumberFormat{Group: true}, true, "true"},
+		{cast.NumberFormat{Group: true}, (*big.Int)(nil), ""},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, format = %+v, input = %#v", i, test.format, test.input)
+
+		caster, err := cast.New(test.format)
+		c.Assert(err, IsNil, errmsg)
+		v, err := caster.ToStringE(test.input)
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+
+	// The NumberFormat applies to all the casts to text, and overrides a
+	// FloatFormat.
+	caster, err := cast.New(cast.FloatFormat{Verb: 'e'}, cast.NumberFormat{Group: true})
+	c.Assert(err, IsNil)
+	b, err := caster.ToBytesE(12345.5)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "12,345.5")
+	s, err := caster.ToStringerE(1234)
+	c.Assert(err, IsNil)
+	c.Assert(s.String(), Equals, "1,234")
+	e, err := caster.ToErrorE(1234)
+	c.Assert(err, IsNil)
+	c.Assert(e.Error(), Equals, "1,234")
+	ss, err := caster.ToStringSliceE([]int{1000, 2000000})
+	c.Assert(err, IsNil)
+	c.Assert(ss, DeepEquals, []string{"1,000", "2,000,000"})
+
+	caster, err = cast.New(caster, cast.NumberFormat{})
+	c.Assert(err, IsNil)
+	v, err := caster.ToStringE(1234)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, "1234")
+
+	for _, f := range []cast.NumberFormat{
+		{Places: -1},
+		{MinIntegerDigits: -1},
+		{Notation: cast.Notation(3)},
+		{Locale: cast.Locale{Decimal: "."}},
+	} {
+		_, err := cast.New(f)
+		c.Assert(err, ErrorMatches, `unsupported option .*`, Commentf("format = %#v", f))
+	}
+}
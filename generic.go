@@ -0,0 +1,165 @@
+package cast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// casters maps the types with a dedicated cast to the method of Caster
+// implementing their ToXxxE function.
+var casters = map[reflect.Type]func(c *Caster, a any) (any, error){
+	reflect.TypeOf(int(0)):                      func(c *Caster, a any) (any, error) { return c.toIntE(a) },
+	reflect.TypeOf(int8(0)):                     func(c *Caster, a any) (any, error) { return c.toInt8E(a) },
+	reflect.TypeOf(int16(0)):                    func(c *Caster, a any) (any, error) { return c.toInt16E(a) },
+	reflect.TypeOf(int32(0)):                    func(c *Caster, a any) (any, error) { return c.toInt32E(a) },
+	reflect.TypeOf(int64(0)):                    func(c *Caster, a any) (any, error) { return c.toInt64E(a) },
+	reflect.TypeOf(uint(0)):                     func(c *Caster, a any) (any, error) { return c.toUintE(a) },
+	reflect.TypeOf(uint8(0)):                    func(c *Caster, a any) (any, error) { return c.toUint8E(a) },
+	reflect.TypeOf(uint16(0)):                   func(c *Caster, a any) (any, error) { return c.toUint16E(a) },
+	reflect.TypeOf(uint32(0)):                   func(c *Caster, a any) (any, error) { return c.toUint32E(a) },
+	reflect.TypeOf(uint64(0)):                   func(c *Caster, a any) (any, error) { return c.toUint64E(a) },
+	reflect.TypeOf(float32(0)):                  func(c *Caster, a any) (any, error) { return c.toFloat32E(a) },
+	reflect.TypeOf(float64(0)):                  func(c *Caster, a any) (any, error) { return c.toFloat64E(a) },
+	reflect.TypeOf((*big.Int)(nil)):             func(c *Caster, a any) (any, error) { return c.toBigIntE(a) },
+	reflect.TypeOf((*big.Float)(nil)):           func(c *Caster, a any) (any, error) { return c.toBigFloatE(a) },
+	reflect.TypeOf((*big.Rat)(nil)):             func(c *Caster, a any) (any, error) { return c.toBigRatE(a) },
+	reflect.TypeOf(decimal.Decimal{}):           func(c *Caster, a any) (any, error) { return c.toDecimalE(a) },
+	reflect.TypeOf(json.Number("")):             func(c *Caster, a any) (any, error) { return c.toJSONNumberE(a) },
+	reflect.TypeOf(complex64(0)):                func(c *Caster, a any) (any, error) { return c.toComplex64E(a) },
+	reflect.TypeOf(complex128(0)):               func(c *Caster, a any) (any, error) { return c.toComplex128E(a) },
+	reflect.TypeOf(false):                       func(c *Caster, a any) (any, error) { return c.toBoolE(a) },
+	reflect.TypeOf(""):                          func(c *Caster, a any) (any, error) { return c.toStringE(a) },
+	reflect.TypeOf([]byte(nil)):                 func(c *Caster, a any) (any, error) { return c.toBytesE(a) },
+	reflect.TypeOf((*fmt.Stringer)(nil)).Elem(): func(c *Caster, a any) (any, error) { return c.toStringerE(a) },
+	reflect.TypeOf((*error)(nil)).Elem():        func(c *Caster, a any) (any, error) { return c.toErrorE(a) },
+	reflect.TypeOf(time.Time{}):                 func(c *Caster, a any) (any, error) { return c.toTimeE(a) },
+	reflect.TypeOf(time.Duration(0)):            func(c *Caster, a any) (any, error) { return c.toDurationE(a) },
+	reflect.TypeOf(byteSize{}):                  func(c *Caster, a any) (any, error) { return c.toByteSizeE(a) },
+	anyType:                                     func(c *Caster, a any) (any, error) { return a, nil },
+}
+
+// To casts an interface to the type T, dispatching to the ToXxxE function of
+// the package matching T.
+//
+// T can be any type with a dedicated cast in this package, such as int8,
+// *big.Rat, string, fmt.Stringer or time.Duration. Casting to any returns the
+// value unchanged. A user-defined type whose underlying type is a basic type
+// or []byte, such as `type UserID string`, is cast through the cast of that
+// underlying type. A slice type, other than one of bytes, is cast element by
+// element like ToIntSliceE, and a map type entry by entry like ToStringMapE.
+// A Null type of database/sql, such as sql.NullInt64, is cast like
+// ToNullInt64E. Other types fail with ErrUnsupported.
+//
+// The options are those of New, such as a Mode, with which a cast that would
+// lose information fails with ErrInexact under Strict, and a RoundingMode for
+// casts to integer types. A *Caster passed as an option supplies all the
+// settings.
+func To[T any](a any, args ...any) (T, error) {
+	c, err := std.with(args)
+	if err != nil {
+		var t T
+		return t, err
+	}
+	return castTo[T](c, a)
+}
+
+// Must casts an interface to the type T like To, but panics if the cast fails.
+func Must[T any](a any, args ...any) T {
+	t, err := To[T](a, args...)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// toType casts an interface to the type t, as described by To.
+func (c *Caster) toType(a any, t reflect.Type) (any, error) {
+	if t != anyType && isNil(a) {
+		if c.nils == NilError && !isNullType(t) {
+			return reflect.Zero(t).Interface(), newCastError(a, t.String(), ErrNil, nil)
+		}
+		// A nil pointer casts like nil.
+		a = nil
+	}
+
+	if v, b, ok := c.exactConversion(a, t); ok {
+		w, err := v.fn(b)
+		if err != nil {
+			return reflect.Zero(t).Interface(), wrapError(a, t.String(), err)
+		}
+		return w, nil
+	}
+
+	r, ok := standardReceiver(a)
+	if !ok || t == anyType {
+		return c.castConverted(a, t)
+	}
+	return c.castStandard(a, r, t)
+}
+
+// castConverted casts an interface to the type t with the casts of the
+// package, consulting the registered conversions when they do not support it.
+func (c *Caster) castConverted(a any, t reflect.Type) (any, error) {
+	v, err := c.castType(a, t)
+	if err != nil && errorKind(err) == ErrUnsupported {
+		if w, ok, err := c.convert(a, t); ok {
+			return w, err
+		}
+	}
+	return v, err
+}
+
+// castType casts an interface to the type t with the casts of the package.
+func (c *Caster) castType(a any, t reflect.Type) (any, error) {
+	if cast, ok := casters[t]; ok {
+		b, rounded := a, false
+		if c.rounding != RoundTruncate && isIntegerType(t) {
+			b, rounded = c.roundInput(a)
+		}
+		v, err := cast(c, b)
+		if err == nil && c.strict {
+			if err := c.checkExact(a, v, t.String()); err != nil {
+				return reflect.Zero(t).Interface(), err
+			}
+		}
+		if err != nil && rounded {
+			err = wrapError(a, t.String(), err)
+		}
+		return v, err
+	}
+
+	if isNullType(t) {
+		return c.toNull(a, t)
+	}
+
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		return toSlice(a, t, func(e any) (any, error) { return c.toType(e, t.Elem()) })
+	}
+
+	if t.Kind() == reflect.Map {
+		return toMap(a, t,
+			func(k any) (any, error) { return c.toType(k, t.Key()) },
+			func(e any) (any, error) { return c.toType(e, t.Elem()) })
+	}
+
+	b, ok := builtinTypes[t.Kind()]
+	if t.Kind() == reflect.Slice {
+		b, ok = bytesType, true
+	}
+	if !ok || !b.ConvertibleTo(t) {
+		return reflect.Zero(t).Interface(), newCastError(a, t.String(), ErrUnsupported, nil)
+	}
+
+	v, err := c.toType(a, b)
+	if err != nil {
+		return reflect.Zero(t).Interface(), wrapError(a, t.String(), err)
+	}
+	return reflect.ValueOf(v).Convert(t).Interface(), nil
+}
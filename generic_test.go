@@ -0,0 +1,96 @@
+package cast_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+func TestTo(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.To[int](v) }, "8", int(8), false},
+		{func(v any) (any, error) { return cast.To[int8](v) }, 8.31, int8(8), false},
+		{func(v any) (any, error) { return cast.To[int16](v) }, uint(8), int16(8), false},
+		{func(v any) (any, error) { return cast.To[int32](v) }, true, int32(1), false},
+		{func(v any) (any, error) { return cast.To[int64](v) }, big.NewInt(8), int64(8), false},
+		{func(v any) (any, error) { return cast.To[uint](v) }, "8", uint(8), false},
+		{func(v any) (any, error) { return cast.To[uint8](v) }, 8, uint8(8), false},
+		{func(v any) (any, error) { return cast.To[uint16](v) }, 8, uint16(8), false},
+		{func(v any) (any, error) { return cast.To[uint32](v) }, 8, uint32(8), false},
+		{func(v any) (any, error) { return cast.To[uint64](v) }, 8, uint64(8), false},
+		{func(v any) (any, error) { return cast.To[float32](v) }, "8.5", float32(8.5), false},
+		{func(v any) (any, error) { return cast.To[float64](v) }, 8, float64(8), false},
+		{func(v any) (any, error) { return cast.To[complex64](v) }, 8, complex64(8), false},
+		{func(v any) (any, error) { return cast.To[complex128](v) }, "(8+1i)", complex128(8 + 1i), false},
+		{func(v any) (any, error) { return cast.To[bool](v) }, "true", true, false},
+		{func(v any) (any, error) { return cast.To[string](v) }, 8, "8", false},
+		{func(v any) (any, error) { return cast.To[time.Duration](v) }, "8s", 8 * time.Second, false},
+		{func(v any) (any, error) { return cast.To[time.Time](v) }, 0, time.Unix(0, 0), false},
+		{func(v any) (any, error) { return cast.To[any](v) }, 8, 8, false},
+		// errors
+		{func(v any) (any, error) { return cast.To[int8](v) }, 300, int8(0), true},
+		{func(v any) (any, error) { return cast.To[uint](v) }, -1, uint(0), true},
+		{func(v any) (any, error) { return cast.To[bool](v) }, "test", false, true},
+		{func(v any) (any, error) { return cast.To[struct{}](v) }, 8, struct{}{}, true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+}
+
+func TestToReferenceTypes(t *testing.T) {
+	c := New(t)
+
+	r, err := cast.To[*big.Rat]("3/8")
+	c.Assert(err, IsNil)
+	c.Assert(r.String(), Equals, "3/8")
+
+	f, err := cast.To[*big.Float](8)
+	c.Assert(err, IsNil)
+	c.Assert(f.String(), Equals, "8")
+
+	b, err := cast.To[[]byte](8)
+	c.Assert(err, IsNil)
+	c.Assert(b, DeepEquals, []byte("8"))
+
+	s, err := cast.To[fmt.Stringer](8)
+	c.Assert(err, IsNil)
+	c.Assert(s.String(), Equals, "8")
+
+	e, err := cast.To[error]("boom")
+	c.Assert(err, IsNil)
+	c.Assert(e, ErrorMatches, "boom")
+
+	_, err = cast.To[chan int](8)
+	c.Assert(errors.Is(err, cast.ErrUnsupported), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast 8 of type int to chan int`)
+}
+
+func TestMust(t *testing.T) {
+	c := New(t)
+
+	c.Assert(cast.Must[int]("8"), Equals, 8)
+	c.Assert(cast.Must[string](8), Equals, "8")
+	c.Assert(func() { cast.Must[int8](300) }, PanicMatches, `unable to cast 300 of type int to int8: value out of range`)
+}
@@ -0,0 +1,199 @@
+package cast
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// The standard conversion interfaces are honoured by the casts of values
+// whose types have no dedicated cast, such as sql.NullInt64 or a uuid.UUID.
+// They are tried from the most precise, and the first one whose value casts
+// to the target type is used:
+//
+//   - driver.Valuer, whose Value is a typed value such as an int64.
+//   - encoding.TextMarshaler, whose text is parsed.
+//   - json.Marshaler, whose JSON is decoded, so a JSON string is its text and
+//     a JSON number is kept exact.
+//   - fmt.Stringer and error, whose text is parsed as the ToXxxE functions do
+//     for any value.
+//   - interface{ Int64() (int64, error) }, as implemented by json.Number.
+//   - interface{ Float64() (float64, error) }.
+//
+// When none of them casts, the error is that of the most precise one.
+
+type int64er interface {
+	Int64() (int64, error)
+}
+
+type float64er interface {
+	Float64() (float64, error)
+}
+
+var (
+	preciseGetters = []func(any) (any, bool){valuerValue, textValue, jsonValue}
+	numberGetters  = []func(any) (any, bool){int64Value, float64Value}
+)
+
+// standardReceiver returns a, or the value it points to, when it implements
+// one of the standard conversion interfaces other than fmt.Stringer and error
+// and has no dedicated cast. The second result reports whether it does.
+func standardReceiver(a any) (any, bool) {
+	if a == nil {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(a)
+	for !implementsStandard(v.Type()) && v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() || !implementsStandard(v.Type()) || hasDedicatedCast(v.Interface()) {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+var standardInterfaces = []reflect.Type{
+	reflect.TypeOf((*driver.Valuer)(nil)).Elem(),
+	reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem(),
+	reflect.TypeOf((*json.Marshaler)(nil)).Elem(),
+	reflect.TypeOf((*int64er)(nil)).Elem(),
+	reflect.TypeOf((*float64er)(nil)).Elem(),
+}
+
+// standardTypes caches the results of implementsStandard by type.
+var standardTypes sync.Map // map[reflect.Type]bool
+
+// implementsStandard reports whether t implements one of the standard
+// conversion interfaces.
+func implementsStandard(t reflect.Type) bool {
+	if ok, cached := standardTypes.Load(t); cached {
+		return ok.(bool)
+	}
+	ok := false
+	for _, i := range standardInterfaces {
+		if t.Implements(i) {
+			ok = true
+			break
+		}
+	}
+	standardTypes.Store(t, ok)
+	return ok
+}
+
+// hasDedicatedCast reports whether a is of a type the casts of the package
+// handle themselves, including the numbers, text, slices and maps of any
+// type.
+func hasDedicatedCast(a any) bool {
+	switch a.(type) {
+	case *big.Int, *big.Float, *big.Rat, decimal.Decimal, *decimal.Decimal, time.Time, *time.Time:
+		return true
+	}
+	switch k := reflect.TypeOf(a).Kind(); k {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		_, ok := builtinTypes[k]
+		return ok
+	}
+}
+
+// castStandard casts a to the type t through the standard conversion
+// interfaces implemented by r, which is a or the value it points to.
+func (c *Caster) castStandard(a, r any, t reflect.Type) (any, error) {
+	var first error
+	try := func(getters []func(any) (any, bool)) (any, bool) {
+		for _, get := range getters {
+			w, ok := get(r)
+			if !ok || reflect.TypeOf(w) == reflect.TypeOf(r) {
+				continue
+			}
+			v, err := c.toType(w, t)
+			if err == nil {
+				return v, true
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		return nil, false
+	}
+
+	if v, ok := try(preciseGetters); ok {
+		return v, nil
+	}
+	v, err := c.castConverted(a, t)
+	if err == nil {
+		return v, nil
+	}
+	if first == nil {
+		first = err
+	}
+	if w, ok := try(numberGetters); ok {
+		return w, nil
+	}
+	return v, wrapError(a, t.String(), first)
+}
+
+func valuerValue(a any) (any, bool) {
+	v, ok := a.(driver.Valuer)
+	if !ok {
+		return nil, false
+	}
+	w, err := v.Value()
+	return w, err == nil
+}
+
+func textValue(a any) (any, bool) {
+	m, ok := a.(encoding.TextMarshaler)
+	if !ok {
+		return nil, false
+	}
+	b, err := m.MarshalText()
+	return string(b), err == nil
+}
+
+// jsonValue decodes the JSON of a, keeping numbers as json.Number so that no
+// precision is lost.
+func jsonValue(a any) (any, bool) {
+	m, ok := a.(json.Marshaler)
+	if !ok {
+		return nil, false
+	}
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return nil, false
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func int64Value(a any) (any, bool) {
+	v, ok := a.(int64er)
+	if !ok {
+		return nil, false
+	}
+	n, err := v.Int64()
+	return n, err == nil
+}
+
+func float64Value(a any) (any, bool) {
+	v, ok := a.(float64er)
+	if !ok {
+		return nil, false
+	}
+	f, err := v.Float64()
+	return f, err == nil
+}
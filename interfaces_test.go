@@ -0,0 +1,109 @@
+package cast_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+type textOnly struct{ text string }
+
+func (v textOnly) MarshalText() ([]byte, error) { return []byte(v.text), nil }
+
+type jsonOnly struct{ json string }
+
+func (v jsonOnly) MarshalJSON() ([]byte, error) { return []byte(v.json), nil }
+
+type numberOnly struct{ f float64 }
+
+func (v numberOnly) Int64() (int64, error) {
+	if v.f != float64(int64(v.f)) {
+		return 0, errors.New("not an integer")
+	}
+	return int64(v.f), nil
+}
+
+func (v numberOnly) Float64() (float64, error) { return v.f, nil }
+
+type valuerStringer struct{}
+
+func (valuerStringer) Value() (driver.Value, error) { return int64(7), nil }
+
+func (valuerStringer) String() string { return "seven" }
+
+func TestStandardInterfaces(t *testing.T) {
+	c := New(t)
+
+	bigInt, _ := new(big.Int).SetString("123456789012345678901", 10)
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.ToIntE(v) }, sql.NullInt64{Int64: 42, Valid: true}, 42, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, &sql.NullInt64{Int64: 42, Valid: true}, 42, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, sql.NullInt64{Int64: 42}, 0, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, (*sql.NullInt64)(nil), 0, false},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, sql.NullString{String: "hi", Valid: true}, "hi", false},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, sql.NullFloat64{Float64: 0.5, Valid: true}, 0.5, false},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, sql.NullBool{Bool: true, Valid: true}, true, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, valuerStringer{}, 7, false},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, valuerStringer{}, "7", false},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, textOnly{"12.5"}, 12.5, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, &textOnly{"0x10"}, 16, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, textOnly{"twelve"}, 0, true},
+		{func(v any) (any, error) { return cast.ToBigRatE(v) }, jsonOnly{`"0.1"`}, big.NewRat(1, 10), false},
+		{func(v any) (any, error) { return cast.ToBigIntE(v) }, jsonOnly{`123456789012345678901`}, bigInt, false},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, jsonOnly{`true`}, true, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, jsonOnly{`[1, "2"]`}, []int{1, 2}, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, numberOnly{3}, 3, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, numberOnly{1.5}, 1, false},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, numberOnly{1.5}, 1.5, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, json.Number("12345678901234567890"), 0, true},
+		{func(v any) (any, error) { return cast.ToBigIntE(v) }, json.Number("12345678901234567890"), new(big.Int).SetUint64(12345678901234567890), false},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, Not(IsNil), errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(fmt.Sprint(v), Equals, fmt.Sprint(test.expect), errmsg)
+	}
+
+	_, err := cast.ToIntE(textOnly{"twelve"})
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast cast_test.textOnly{text:"twelve"} of type cast_test.textOnly to int: strconv.ParseInt: parsing "twelve": invalid syntax`)
+
+	// NULL is nil.
+	strict, err := cast.New(cast.NilError)
+	c.Assert(err, IsNil)
+	_, err = strict.ToIntE(sql.NullInt64{})
+	c.Assert(errors.Is(err, cast.ErrNil), IsTrue)
+
+	// Values are kept as they are when no cast is asked for.
+	m, err := cast.ToStringMapE(map[string]any{"n": sql.NullInt64{Int64: 1, Valid: true}})
+	c.Assert(err, IsNil)
+	c.Assert(m["n"], Equals, sql.NullInt64{Int64: 1, Valid: true})
+
+	var out struct {
+		Count int
+		Name  string
+	}
+	err = cast.Decode(map[string]any{"count": sql.NullInt64{Int64: 3, Valid: true}, "name": sql.NullString{String: "x", Valid: true}}, &out)
+	c.Assert(err, IsNil)
+	c.Assert(out.Count, Equals, 3)
+	c.Assert(out.Name, Equals, "x")
+}
@@ -0,0 +1,103 @@
+package cast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// ToJSONNumber casts an interface to a json.Number type.
+func ToJSONNumber(a any) json.Number {
+	v, _ := ToJSONNumberE(a)
+	return v
+}
+
+// ToJSONNumberE casts an interface to a json.Number type.
+//
+// Integers and decimals are written in full, and floats as encoding/json
+// writes them. Text is parsed as by ToBigRatE, so "0x10" is 16; a value with no
+// finite decimal form, such as "1/3", is rounded to DivisionPrecision digits
+// of decimal.Decimal. NaN and the infinities fail with ErrNotFinite.
+func ToJSONNumberE(a any) (json.Number, error) {
+	return std.ToJSONNumberE(a)
+}
+
+// ToJSONNumberE casts an interface to a json.Number type with the settings of
+// c.
+func (c *Caster) ToJSONNumberE(a any) (json.Number, error) {
+	return castBuiltin(c, a, (*Caster).toJSONNumberE)
+}
+
+func (c *Caster) toJSONNumberE(a any) (json.Number, error) {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		n, err := c.toJSONNumberE(b)
+		return n, wrapError(a, "json.Number", err)
+	}
+
+	switch v := a.(type) {
+	case json.Number:
+		if !isJSONNumber(string(v)) {
+			return "", newCastError(a, "json.Number", ErrSyntax, nil)
+		}
+		return v, nil
+	case int:
+		return json.Number(strconv.Itoa(v)), nil
+	case int8:
+		return json.Number(strconv.FormatInt(int64(v), 10)), nil
+	case int16:
+		return json.Number(strconv.FormatInt(int64(v), 10)), nil
+	case int32:
+		return json.Number(strconv.FormatInt(int64(v), 10)), nil
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10)), nil
+	case uint:
+		return json.Number(strconv.FormatUint(uint64(v), 10)), nil
+	case uint8:
+		return json.Number(strconv.FormatUint(uint64(v), 10)), nil
+	case uint16:
+		return json.Number(strconv.FormatUint(uint64(v), 10)), nil
+	case uint32:
+		return json.Number(strconv.FormatUint(uint64(v), 10)), nil
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10)), nil
+	case float32:
+		return marshalJSONNumber(v, a)
+	case float64:
+		return marshalJSONNumber(v, a)
+	case *big.Int:
+		if v == nil {
+			return "", newCastError(a, "json.Number", ErrUnsupported, nil)
+		}
+		return json.Number(v.String()), nil
+	case decimal.Decimal:
+		return json.Number(v.String()), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return "", newCastError(a, "json.Number", ErrUnsupported, nil)
+		}
+		return json.Number(v.String()), nil
+	case nil:
+		return "", nil
+	case *big.Float, *big.Rat, complex64, complex128, bool, string, []byte, fmt.Stringer, error:
+		r, err := c.toBigRatE(v)
+		if err != nil {
+			return "", wrapError(a, "json.Number", err)
+		}
+		return json.Number(ratToDecimal(r).String()), nil
+	default:
+		return "", newCastError(a, "json.Number", ErrUnsupported, nil)
+	}
+}
+
+// marshalJSONNumber formats the float f as encoding/json does.
+func marshalJSONNumber(f any, a any) (json.Number, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return "", newCastError(a, "json.Number", ErrNotFinite, nil)
+	}
+	return json.Number(b), nil
+}
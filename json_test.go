@@ -0,0 +1,126 @@
+package cast_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
+)
+
+func TestJSONNumberInput(t *testing.T) {
+	c := New(t)
+
+	bigInt, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  json.Number
+		expect any
+		kind   cast.ErrorKind
+	}{
+		{func(v any) (any, error) { return cast.ToIntE(v) }, "12345", 12345, 0},
+		{func(v any) (any, error) { return cast.ToInt64E(v) }, "9223372036854775807", int64(math.MaxInt64), 0},
+		{func(v any) (any, error) { return cast.ToInt64E(v) }, "9223372036854775808", int64(0), cast.ErrOverflow},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, "1.5e2", 150, 0},
+		{func(v any) (any, error) { return cast.ToUint64E(v) }, "18446744073709551615", uint64(math.MaxUint64), 0},
+		{func(v any) (any, error) { return cast.ToUintE(v) }, "-1", uint(0), cast.ErrNegative},
+		{func(v any) (any, error) { return cast.ToFloat32E(v) }, "0.1", float32(0.1), 0},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, "0.1", 0.1, 0},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, "1e400", 0.0, cast.ErrOverflow},
+		{func(v any) (any, error) { return cast.ToBigIntE(v) }, "123456789012345678901234567890", bigInt, 0},
+		{func(v any) (any, error) { return cast.ToBigFloatE(v) }, "-0.5", big.NewFloat(-0.5), 0},
+		{func(v any) (any, error) { return cast.ToBigRatE(v) }, "0.1", big.NewRat(1, 10), 0},
+		{func(v any) (any, error) { return cast.ToDecimalE(v) }, "1.23456789012345678901", decimal.RequireFromString("1.23456789012345678901"), 0},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, "1.0", "1.0", 0},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, "0.0", false, 0},
+		{func(v any) (any, error) { return cast.ToComplex64E(v) }, "1.5", complex64(1.5), 0},
+		{func(v any) (any, error) { return cast.ToComplex128E(v) }, "-2e1", complex(-20, 0), 0},
+		{func(v any) (any, error) { return cast.ToDurationE(v) }, "1000", time.Microsecond, 0},
+		// not JSON numbers
+		{func(v any) (any, error) { return cast.ToIntE(v) }, "0x10", 0, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, "08", 0, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, "+1", 0.0, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, ".5", 0.0, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToBigRatE(v) }, "1/2", big.NewRat(0, 1), cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToDecimalE(v) }, "NaN", decimal.Zero, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, "", 0, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, "true", false, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToComplex64E(v) }, "1+2i", complex64(0), cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToComplex128E(v) }, "inf", complex128(0), cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToDurationE(v) }, "5s", time.Duration(0), cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToTimeE(v) }, "0x10", time.Time{}, cast.ErrSyntax},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %q", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.kind != 0 {
+			c.Assert(errors.Is(err, test.kind), IsTrue, Commentf("i = %d, input = %q, err = %v", i, test.input, err))
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(fmt.Sprint(v), Equals, fmt.Sprint(test.expect), errmsg)
+	}
+
+	m, err := cast.ToStringMapE(`{"id": 123456789012345678}`)
+	c.Assert(err, IsNil)
+	id, err := cast.ToInt64E(m["id"])
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, int64(123456789012345678))
+}
+
+func TestToJSONNumberE(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		input  any
+		expect json.Number
+		kind   cast.ErrorKind
+	}{
+		{42, "42", 0},
+		{int8(-8), "-8", 0},
+		{uint64(math.MaxUint64), "18446744073709551615", 0},
+		{0.1, "0.1", 0},
+		{float32(0.1), "0.1", 0},
+		{1e21, "1e+21", 0},
+		{big.NewRat(1, 4), "0.25", 0},
+		{big.NewFloat(1.5), "1.5", 0},
+		{decimal.RequireFromString("1.50"), "1.5", 0},
+		{true, "1", 0},
+		{"0x10", "16", 0},
+		{"1e3", "1000", 0},
+		{json.Number("-1.5e-3"), "-1.5e-3", 0},
+		{nil, "", 0},
+		{math.NaN(), "", cast.ErrNotFinite},
+		{math.Inf(-1), "", cast.ErrNotFinite},
+		{"abc", "", cast.ErrSyntax},
+		{json.Number("01"), "", cast.ErrSyntax},
+		{struct{}{}, "", cast.ErrUnsupported},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := cast.ToJSONNumberE(test.input)
+		if test.kind != 0 {
+			c.Assert(errors.Is(err, test.kind), IsTrue, errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, Equals, test.expect, errmsg)
+		c.Assert(cast.ToJSONNumber(test.input), Equals, test.expect, errmsg)
+	}
+
+	n, err := cast.To[json.Number]("2.50")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, json.Number("2.5"))
+	_, err = cast.To[json.Number]("1/3", cast.Strict)
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+}
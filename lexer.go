@@ -0,0 +1,328 @@
+package cast
+
+import "strings"
+
+// The strings accepted by the numeric and bool casts follow the grammar below,
+// in which letters other than those of bool are matched case-insensitively:
+//
+//	number    = bool | real | complex .
+//	bool      = "t" | "T" | "true" | "True" | "TRUE" |
+//	            "f" | "F" | "false" | "False" | "FALSE" .
+//	real      = [ sign ] ( integer | float | rational ) | special .
+//	sign      = "+" | "-" .
+//	integer   = decimals | "0" octdigits |
+//	            "0x" hexdigits | "0o" octdigits | "0b" bindigits .
+//	float     = mantissa [ exponent ] [ "f" ] | decimals exponent [ "f" ] |
+//	            decimals "f" | "0x" hexmantissa "p" [ sign ] decimals .
+//	mantissa  = decimals "." [ decimals ] | "." decimals .
+//	exponent  = "e" [ sign ] decimals .
+//	rational  = integer "/" integer .
+//	special   = [ sign ] ( "inf" | "infinity" ) | "nan" .
+//	complex   = "(" imaginary ")" | imaginary .
+//	imaginary = [ real ] sign real "i" | real "i" .
+//
+// As in Go literals, single underscores may separate the digits, and the
+// digits after a base prefix may start with one, so "1_000" is 1000 and
+// "0x_ff" is 255. An integer with a leading zero and no base prefix is octal,
+// as strconv.ParseInt reads it with base 0, so "0755" is 493 and "08" is
+// invalid; a float is decimal even with leading zeros. The optional "f"
+// suffix of a decimal float is ignored, so "1e3f" is 1000. The components of
+// a complex number are parsed by strconv.ParseComplex and so follow its syntax
+// rather than this one, which only decides the class; the imaginary part may
+// also be a signed NaN, as strconv.FormatComplex writes it.
+//
+// A Caster may replace the bool words with its BoolWords and reject some of
+// the base prefixes with its Bases, an integer with a leading zero being
+// decimal when they reject base 8. With a Locale, it also accepts the numbers
+// of that locale, such as "1.234,56" or the parts of "1,5+2i", by rewriting
+// them in this grammar. With Proportions, the casts to floats and rationals
+// also accept numbers of this grammar followed by a percent, per-mille or
+// basis-point suffix, and ratios of two of them separated by ":".
+
+// tokenKind is the class of a string of the grammar.
+type tokenKind int
+
+const (
+	tokenInvalid tokenKind = iota
+	tokenBool
+	tokenInt
+	tokenFloat
+	tokenSpecial
+	tokenRat
+	tokenComplex
+)
+
+var (
+	decimalDigits = "0123456789"
+	baseDigits    = map[byte]string{
+		'x': "0123456789abcdefABCDEF",
+		'o': "01234567",
+		'b': "01",
+	}
+)
+
+// lex returns the class of s, or tokenInvalid when s is not of the grammar.
+func lex(s string) tokenKind {
+	switch s {
+	case "t", "T", "true", "True", "TRUE", "f", "F", "false", "False", "FALSE":
+		return tokenBool
+	}
+	if k := lexReal(s); k != tokenInvalid {
+		return k
+	}
+	if lexComplex(s) {
+		return tokenComplex
+	}
+	return tokenInvalid
+}
+
+// lexReal returns the class of s when it is a real number.
+func lexReal(s string) tokenKind {
+	if isSpecial(s) {
+		return tokenSpecial
+	}
+
+	sc := scanner{s: s}
+	sc.accept("+-")
+	k := sc.number()
+	if k == tokenInt && sc.accept("/") {
+		if sc.number() != tokenInt {
+			return tokenInvalid
+		}
+		k = tokenRat
+	}
+	if !sc.done() {
+		return tokenInvalid
+	}
+	return k
+}
+
+// lexComplex reports whether s has the shape of a complex number.
+func lexComplex(s string) bool {
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = s[1 : len(s)-1]
+	}
+	if !strings.HasSuffix(s, "i") {
+		return false
+	}
+	s = s[:len(s)-1]
+
+	if isComponent(s) {
+		return true
+	}
+	for i := 1; i < len(s); i++ {
+		if (s[i] == '+' || s[i] == '-') && isComponent(s[:i]) && isComponent(s[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isComponent reports whether s may be the real or imaginary part of a
+// complex number.
+func isComponent(s string) bool {
+	// strconv.FormatComplex writes a NaN imaginary part with a sign.
+	if strings.EqualFold(strings.TrimLeft(s, "+-"), "nan") {
+		return true
+	}
+	switch lexReal(s) {
+	case tokenInt, tokenFloat, tokenSpecial:
+		return true
+	}
+	return false
+}
+
+func isSpecial(s string) bool {
+	switch strings.ToLower(s) {
+	case "inf", "+inf", "-inf", "infinity", "+infinity", "-infinity", "nan":
+		return true
+	}
+	return false
+}
+
+// hasPrefix reports whether a number in s has a base prefix with one of the
+// letters in prefixes.
+func hasPrefix(s, prefixes string) bool {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] != '0' || strings.IndexByte(prefixes, s[i+1]|0x20) < 0 {
+			continue
+		}
+		if i == 0 || strings.IndexByte(decimalDigits+".", s[i-1]) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isLegacyOctal reports whether the unsigned integer s is octal by its leading
+// zero alone, without a base prefix.
+func isLegacyOctal(s string) bool {
+	return len(s) > 1 && s[0] == '0' && strings.IndexByte(decimalDigits+"_", s[1]) >= 0
+}
+
+// hasOctalDigits reports whether the integers in s, of the integer or rational
+// class, that are octal by their leading zero only have octal digits.
+func hasOctalDigits(s string) bool {
+	for _, n := range strings.Split(s, "/") {
+		n = strings.TrimLeft(n, "+-")
+		if isLegacyOctal(n) && strings.ContainsAny(n, "89") {
+			return false
+		}
+	}
+	return true
+}
+
+// isPlainDecimal reports whether s is a decimal integer without leading zeros
+// or a decimal float without exponent, with an optional sign, which strconv
+// parses to the value of the grammar.
+func isPlainDecimal(s string) bool {
+	sc := scanner{s: s}
+	sc.accept("+-")
+	start := sc.i
+	n := sc.run(decimalDigits)
+	if sc.accept(".") {
+		n += sc.run(decimalDigits)
+	} else if n > 1 && sc.s[start] == '0' {
+		return false
+	}
+	return n > 0 && sc.done()
+}
+
+// isJSONNumber reports whether s has the syntax of a number in JSON, which
+// json.Number values are expected to have.
+func isJSONNumber(s string) bool {
+	sc := scanner{s: s}
+	sc.accept("-")
+	if !sc.accept("0") {
+		if !sc.accept("123456789") {
+			return false
+		}
+		sc.run(decimalDigits)
+	}
+	if sc.accept(".") && sc.run(decimalDigits) == 0 {
+		return false
+	}
+	if sc.accept("eE") {
+		sc.accept("+-")
+		if sc.run(decimalDigits) == 0 {
+			return false
+		}
+	}
+	return sc.done()
+}
+
+// trimFloatSuffix removes the "f" suffix of a decimal float.
+func trimFloatSuffix(s string) string {
+	if strings.ContainsAny(s, "xX") {
+		return s
+	}
+	return strings.TrimRight(s, "fF")
+}
+
+// scanner reads the unsigned numbers of the grammar from s.
+type scanner struct {
+	s string
+	i int
+}
+
+func (sc *scanner) done() bool {
+	return sc.i == len(sc.s)
+}
+
+// accept consumes the next byte if it is one of chars.
+func (sc *scanner) accept(chars string) bool {
+	if sc.i < len(sc.s) && strings.IndexByte(chars, sc.s[sc.i]) >= 0 {
+		sc.i++
+		return true
+	}
+	return false
+}
+
+// run consumes the bytes in chars and returns how many there were.
+func (sc *scanner) run(chars string) int {
+	start := sc.i
+	for sc.accept(chars) {
+	}
+	return sc.i - start
+}
+
+// digits consumes digits in chars that single underscores may separate and
+// returns how many digits there were. The second result is false when an
+// underscore is not between two digits, or after a base prefix when prefixed.
+func (sc *scanner) digits(chars string, prefixed bool) (int, bool) {
+	start := sc.i
+	sc.run(chars + "_")
+	run := sc.s[start:sc.i]
+	ok := !strings.Contains(run, "__") && !strings.HasSuffix(run, "_") &&
+		(prefixed || !strings.HasPrefix(run, "_"))
+	return len(run) - strings.Count(run, "_"), ok
+}
+
+// number consumes an integer or a float and returns its class.
+func (sc *scanner) number() tokenKind {
+	if sc.i+1 < len(sc.s) && sc.s[sc.i] == '0' {
+		if digits, ok := baseDigits[sc.s[sc.i+1]|0x20]; ok {
+			sc.i += 2
+			return sc.prefixed(digits)
+		}
+	}
+
+	k := tokenInt
+	n, ok := sc.digits(decimalDigits, false)
+	if !ok {
+		return tokenInvalid
+	}
+	if sc.accept(".") {
+		k = tokenFloat
+		m, ok := sc.digits(decimalDigits, false)
+		if !ok {
+			return tokenInvalid
+		}
+		n += m
+	}
+	if n == 0 {
+		return tokenInvalid
+	}
+	if sc.accept("eE") {
+		k = tokenFloat
+		sc.accept("+-")
+		if n, ok := sc.digits(decimalDigits, false); n == 0 || !ok {
+			return tokenInvalid
+		}
+	}
+	if sc.accept("fF") {
+		k = tokenFloat
+	}
+	return k
+}
+
+// prefixed consumes the digits after a base prefix, and the fraction and
+// binary exponent of a hexadecimal float.
+func (sc *scanner) prefixed(digits string) tokenKind {
+	n, ok := sc.digits(digits, true)
+	if !ok {
+		return tokenInvalid
+	}
+	if digits != baseDigits['x'] || sc.done() || !strings.ContainsRune(".pP", rune(sc.s[sc.i])) {
+		if n == 0 {
+			return tokenInvalid
+		}
+		return tokenInt
+	}
+
+	if sc.accept(".") {
+		m, ok := sc.digits(digits, false)
+		if !ok {
+			return tokenInvalid
+		}
+		n += m
+	}
+	if n == 0 || !sc.accept("pP") {
+		return tokenInvalid
+	}
+	sc.accept("+-")
+	if n, ok := sc.digits(decimalDigits, false); n == 0 || !ok {
+		return tokenInvalid
+	}
+	return tokenFloat
+}
@@ -0,0 +1,238 @@
+package cast
+
+import "strings"
+
+// A Locale is an option of New describing how numbers are written in a
+// language, such as "1.234,56" in German. With a Locale, the numeric casts of
+// strings also accept numbers made of:
+//
+//   - an optional sign, leading or trailing, or parentheses around the number
+//     making it negative, as in accounting;
+//   - the integer digits, either with no separator or grouped by Group at the
+//     positions given by Grouping;
+//   - an optional fraction after the Decimal mark.
+//
+// Strings with neither the Group separator nor a Decimal mark other than "."
+// follow the grammar documented in lexer.go, so "1e3" and "0x10" keep their
+// meaning. A Group made of a space also matches the no-break spaces U+00A0
+// and U+202F and the plain space, and the minus sign U+2212 is accepted for
+// "-". The zero Locale restores the default parsing.
+type Locale struct {
+	// Tag is the BCP 47 language tag of the locale, such as "de-DE".
+	Tag string
+	// Decimal is the decimal mark.
+	Decimal string
+	// Group is the separator of the groups of integer digits.
+	Group string
+	// Grouping holds the sizes of the groups of integer digits from the
+	// right, the last one repeating, such as [3] for "1,234,567" or [3, 2]
+	// for "12,34,567".
+	Grouping []int
+}
+
+var locales = []Locale{
+	{Tag: "en", Decimal: ".", Group: ",", Grouping: []int{3}},
+	{Tag: "en-US", Decimal: ".", Group: ",", Grouping: []int{3}},
+	{Tag: "en-GB", Decimal: ".", Group: ",", Grouping: []int{3}},
+	{Tag: "en-IN", Decimal: ".", Group: ",", Grouping: []int{3, 2}},
+	{Tag: "de", Decimal: ",", Group: ".", Grouping: []int{3}},
+	{Tag: "de-DE", Decimal: ",", Group: ".", Grouping: []int{3}},
+	{Tag: "de-CH", Decimal: ".", Group: "\u2019", Grouping: []int{3}},
+	{Tag: "fr", Decimal: ",", Group: "\u202f", Grouping: []int{3}},
+	{Tag: "fr-FR", Decimal: ",", Group: "\u202f", Grouping: []int{3}},
+	{Tag: "es-ES", Decimal: ",", Group: ".", Grouping: []int{3}},
+	{Tag: "it-IT", Decimal: ",", Group: ".", Grouping: []int{3}},
+	{Tag: "pt-BR", Decimal: ",", Group: ".", Grouping: []int{3}},
+	{Tag: "ja-JP", Decimal: ".", Group: ",", Grouping: []int{3}},
+}
+
+// LookupLocale returns the built-in Locale of the language tag, such as
+// "de-DE" or "en_IN", matched case-insensitively. A tag without a built-in
+// Locale gets that of its language, so "fr-BE" gets "fr".
+func LookupLocale(tag string) (Locale, bool) {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	lang, _, hasRegion := strings.Cut(tag, "-")
+	for _, try := range []string{tag, lang} {
+		for _, l := range locales {
+			if strings.EqualFold(l.Tag, try) {
+				l.Grouping = append([]int(nil), l.Grouping...)
+				return l, true
+			}
+		}
+		if !hasRegion {
+			break
+		}
+	}
+	return Locale{}, false
+}
+
+// valid reports whether l can be used to parse numbers.
+func (l Locale) valid() bool {
+	if l.Decimal == "" || l.Group == "" || l.Decimal == l.Group || len(l.Grouping) == 0 {
+		return false
+	}
+	for _, sep := range []string{l.Decimal, l.Group} {
+		if strings.ContainsAny(sep, decimalDigits+"+-()\u2212") {
+			return false
+		}
+	}
+	for _, n := range l.Grouping {
+		if n <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// groupSpaces holds the spaces matched by a Group made of one of them.
+var groupSpaces = []string{" ", "\u00a0", "\u202f"}
+
+// normalize returns s with the spaces matched by the Group of l replaced by
+// it, and the minus sign U+2212 by "-".
+func (l *Locale) normalize(s string) string {
+	for _, space := range groupSpaces {
+		if l.Group != space {
+			continue
+		}
+		for _, other := range groupSpaces {
+			s = strings.ReplaceAll(s, other, space)
+		}
+	}
+	return strings.ReplaceAll(s, "\u2212", "-")
+}
+
+// localized returns s written in the grammar documented in lexer.go when it
+// is a number of the locale of p, or s itself when it follows the grammar.
+// The second result is false when s is neither.
+func (p decimalParser) localized(s string) (string, bool) {
+	l := p.locale
+	if l == nil {
+		return s, true
+	}
+
+	s = l.normalize(s)
+	if n, ok := l.canonical(s); ok {
+		return n, true
+	}
+	if n, ok := l.canonicalComplex(s); ok {
+		return n, true
+	}
+	if strings.Contains(s, l.Group) || l.Decimal != "." && strings.Contains(s, l.Decimal) {
+		return s, false
+	}
+	return s, true
+}
+
+// canonical returns the number s of the locale in the grammar documented in
+// lexer.go, reporting whether s is one.
+func (l *Locale) canonical(s string) (string, bool) {
+	var sign string
+	switch {
+	case strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"):
+		s, sign = s[1:len(s)-1], "-"
+	case strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-"):
+		s, sign = s[1:], s[:1]
+	case strings.HasSuffix(s, "+") || strings.HasSuffix(s, "-"):
+		s, sign = s[:len(s)-1], s[len(s)-1:]
+	}
+
+	integer, fraction, _ := strings.Cut(s, l.Decimal)
+	if integer == "" && fraction == "" || !isDigits(fraction) {
+		return "", false
+	}
+	integer, ok := l.ungroup(integer)
+	if !ok {
+		return "", false
+	}
+	if sign == "+" {
+		sign = ""
+	}
+	if fraction == "" {
+		return sign + integer, true
+	}
+	return sign + integer + "." + fraction, true
+}
+
+// canonicalComplex returns the complex number s, whose parts may be numbers
+// of l, in the grammar documented in lexer.go, reporting whether s is one.
+func (l *Locale) canonicalComplex(s string) (string, bool) {
+	open, end := "", "i"
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s, open, end = s[1:len(s)-1], "(", "i)"
+	}
+	if !strings.HasSuffix(s, "i") {
+		return "", false
+	}
+	s = s[:len(s)-1]
+
+	if im, ok := l.complexPart(s); ok {
+		return open + im + end, true
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] != '+' && s[i] != '-' {
+			continue
+		}
+		re, ok := l.complexPart(s[:i])
+		if !ok {
+			continue
+		}
+		if im, ok := l.complexPart(s[i+1:]); ok && !strings.ContainsAny(im[:1], "+-") {
+			return open + re + s[i:i+1] + im + end, true
+		}
+	}
+	return "", false
+}
+
+// complexPart returns the real or imaginary part s of a complex number in the
+// grammar, s being a number of l with an optional leading sign or a part
+// already in the grammar.
+func (l *Locale) complexPart(s string) (string, bool) {
+	sign, n := "", s
+	if strings.HasPrefix(n, "+") || strings.HasPrefix(n, "-") {
+		sign, n = n[:1], n[1:]
+	}
+	if n != "" && !strings.ContainsAny(n[:1], "+-(") && !strings.ContainsAny(n[len(n)-1:], "+-)") {
+		if c, ok := l.canonical(n); ok {
+			return sign + c, true
+		}
+	}
+	if strings.Contains(s, l.Group) || l.Decimal != "." && strings.Contains(s, l.Decimal) {
+		return "", false
+	}
+	return s, isComponent(s)
+}
+
+// ungroup returns the integer digits of s without the Group separators of l,
+// reporting whether they are absent or at the positions given by Grouping.
+func (l *Locale) ungroup(s string) (string, bool) {
+	if !strings.Contains(s, l.Group) {
+		return s, isDigits(s)
+	}
+
+	groups := strings.Split(s, l.Group)
+	for i := len(groups) - 1; i >= 0; i-- {
+		g, n := groups[i], l.groupSize(len(groups)-1-i)
+		if !isDigits(g) || g == "" || len(g) > n || i > 0 && len(g) != n {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// groupSize returns the size of the i-th group of digits from the right.
+func (l *Locale) groupSize(i int) int {
+	if i >= len(l.Grouping) {
+		i = len(l.Grouping) - 1
+	}
+	return l.Grouping[i]
+}
+
+// isDigits reports whether s is made of decimal digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package cast_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
+)
+
+func TestLocale(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tag    string
+		tove   func(*cast.Caster, any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1,234.56", 1234.56, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1,234,567", 1234567, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1234567", 1234567, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "(1,234)", -1234, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1,234-", -1234, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "+1,234", 1234, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "−5", -5, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "0x10", 16, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1.5e3", 1500.0, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToBoolE(v) }, "true", true, false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToComplex128E(v) }, "1,234.5-1i", complex(1234.5, -1), false},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "12,34", 0, true},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1,2345", 0, true},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, ",123", 0, true},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "-(1)", 0, true},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "-1-", 0, true},
+		{"en", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1,234.5e3", 0.0, true},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1.234,56", 1234.56, false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToDecimalE(v) }, "-1.234.567,891", decimal.RequireFromString("-1234567.891"), false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToBigRatE(v) }, "0,1", big.NewRat(1, 10), false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, ",5", 0.5, false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1e3", 1000, false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToComplex128E(v) }, "1,5+2i", complex(1.5, 2), false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToComplex128E(v) }, "(-1.234,5-0,25i)", complex(-1234.5, -0.25), false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToComplex64E(v) }, "2,5i", complex64(complex(0, 2.5)), false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToComplex128E(v) }, "1e3+1,5i", complex(1000, 1.5), false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToComplex128E(v) }, "1,5+-2i", complex128(0), true},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToComplex128E(v) }, "1.5+2i", complex128(0), true},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1.5", 0.0, true},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1,234.56", 0.0, true},
+		{"fr-FR", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1 234,56", 1234.56, false},
+		{"fr-FR", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1 234,56", 1234.56, false},
+		{"fr-FR", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1 234 567", 1234567.0, false},
+		{"fr-FR", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1 23", 0, true},
+		{"en-IN", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "12,34,567", 1234567, false},
+		{"en-IN", func(c *cast.Caster, v any) (any, error) { return c.ToFloat64E(v) }, "1,00,000.5", 100000.5, false},
+		{"en-IN", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1,234,567", 0, true},
+		{"de-CH", func(c *cast.Caster, v any) (any, error) { return c.ToIntE(v) }, "1’234", 1234, false},
+		{"de-DE", func(c *cast.Caster, v any) (any, error) { return c.ToIntSliceE(v) }, []string{"1.000", "(2.000)"}, []int{1000, -2000}, false},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, tag = %s, input = %#v", i, test.tag, test.input)
+
+		l, ok := cast.LookupLocale(test.tag)
+		c.Assert(ok, IsTrue, errmsg)
+		caster, err := cast.New(l)
+		c.Assert(err, IsNil, errmsg)
+		v, err := test.tove(caster, test.input)
+		if test.iserr {
+			c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue, errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(fmt.Sprint(v), Equals, fmt.Sprint(test.expect), errmsg)
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	c := New(t)
+
+	l, ok := cast.LookupLocale("de_de")
+	c.Assert(ok, IsTrue)
+	c.Assert(l, DeepEquals, cast.Locale{Tag: "de-DE", Decimal: ",", Group: ".", Grouping: []int{3}})
+	l, ok = cast.LookupLocale("fr-BE")
+	c.Assert(ok, IsTrue)
+	c.Assert(l.Tag, Equals, "fr")
+	_, ok = cast.LookupLocale("xx-YY")
+	c.Assert(ok, IsFalse)
+
+	// The built-in locales cannot be changed through the values returned.
+	l, _ = cast.LookupLocale("en")
+	l.Grouping[0] = 2
+	l, _ = cast.LookupLocale("en")
+	c.Assert(l.Grouping, DeepEquals, []int{3})
+
+	for _, l := range []cast.Locale{
+		{Decimal: ",", Group: ",", Grouping: []int{3}},
+		{Decimal: ",", Group: ".", Grouping: []int{0}},
+		{Decimal: ",", Group: "."},
+		{Decimal: "-", Group: ".", Grouping: []int{3}},
+		{Decimal: "1", Group: ".", Grouping: []int{3}},
+	} {
+		_, err := cast.New(l)
+		c.Assert(err, ErrorMatches, `unsupported option .*`, Commentf("locale = %#v", l))
+	}
+
+	de, _ := cast.LookupLocale("de")
+	caster, err := cast.New(de, cast.Locale{})
+	c.Assert(err, IsNil)
+	f, err := caster.ToFloat64E("1.5")
+	c.Assert(err, IsNil)
+	c.Assert(f, Equals, 1.5)
+
+	// Strict still rejects numbers that lose information.
+	caster, err = cast.New(de, cast.Strict)
+	c.Assert(err, IsNil)
+	_, err = caster.ToIntE("1.234,5")
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+	n, err := caster.ToIntE("1.234,0")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1234)
+}
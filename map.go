@@ -0,0 +1,169 @@
+package cast
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// ToStringMap casts an interface to a map[string]any type.
+func ToStringMap(a any) map[string]any {
+	v, _ := ToStringMapE(a)
+	return v
+}
+
+// ToStringMapE casts an interface to a map[string]any type. The values are
+// kept as they are, with JSON numbers decoded as json.Number.
+func ToStringMapE(a any) (map[string]any, error) {
+	return std.ToStringMapE(a)
+}
+
+// ToStringMapE casts an interface to a map[string]any type with the settings of c.
+func (c *Caster) ToStringMapE(a any) (map[string]any, error) {
+	return castTo[map[string]any](c, a)
+}
+
+// ToStringMapString casts an interface to a map[string]string type.
+func ToStringMapString(a any) map[string]string {
+	v, _ := ToStringMapStringE(a)
+	return v
+}
+
+// ToStringMapStringE casts an interface to a map[string]string type.
+func ToStringMapStringE(a any) (map[string]string, error) {
+	return std.ToStringMapStringE(a)
+}
+
+// ToStringMapStringE casts an interface to a map[string]string type with the settings of c.
+func (c *Caster) ToStringMapStringE(a any) (map[string]string, error) {
+	return castTo[map[string]string](c, a)
+}
+
+// ToStringMapInt casts an interface to a map[string]int type.
+func ToStringMapInt(a any) map[string]int {
+	v, _ := ToStringMapIntE(a)
+	return v
+}
+
+// ToStringMapIntE casts an interface to a map[string]int type.
+func ToStringMapIntE(a any) (map[string]int, error) {
+	return std.ToStringMapIntE(a)
+}
+
+// ToStringMapIntE casts an interface to a map[string]int type with the settings of c.
+func (c *Caster) ToStringMapIntE(a any) (map[string]int, error) {
+	return castTo[map[string]int](c, a)
+}
+
+// ToStringMapBool casts an interface to a map[string]bool type.
+func ToStringMapBool(a any) map[string]bool {
+	v, _ := ToStringMapBoolE(a)
+	return v
+}
+
+// ToStringMapBoolE casts an interface to a map[string]bool type.
+func ToStringMapBoolE(a any) (map[string]bool, error) {
+	return std.ToStringMapBoolE(a)
+}
+
+// ToStringMapBoolE casts an interface to a map[string]bool type with the settings of c.
+func (c *Caster) ToStringMapBoolE(a any) (map[string]bool, error) {
+	return castTo[map[string]bool](c, a)
+}
+
+// ToStringMapStringSlice casts an interface to a map[string][]string type.
+func ToStringMapStringSlice(a any) map[string][]string {
+	v, _ := ToStringMapStringSliceE(a)
+	return v
+}
+
+// ToStringMapStringSliceE casts an interface to a map[string][]string type.
+// Each value is cast with ToStringSliceE.
+func ToStringMapStringSliceE(a any) (map[string][]string, error) {
+	return std.ToStringMapStringSliceE(a)
+}
+
+// ToStringMapStringSliceE casts an interface to a map[string][]string type with the settings of c.
+func (c *Caster) ToStringMapStringSliceE(a any) (map[string][]string, error) {
+	return castTo[map[string][]string](c, a)
+}
+
+// toMap casts an interface to the map type t, casting the keys with castKey
+// and the values with castValue. A map of any type has its entries cast, and a
+// string or []byte is decoded as a JSON object. A nil value is a nil map.
+func toMap(a any, t reflect.Type, castKey, castValue func(any) (any, error)) (any, error) {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		v, err := toMap(b, t, castKey, castValue)
+		return v, wrapError(a, t.String(), err)
+	}
+
+	var src reflect.Value
+	switch v := a.(type) {
+	case string:
+		m, err := decodeJSONObject([]byte(v))
+		if err != nil {
+			return reflect.Zero(t).Interface(), wrapError(a, t.String(), err)
+		}
+		src = reflect.ValueOf(m)
+	case []byte:
+		m, err := decodeJSONObject(v)
+		if err != nil {
+			return reflect.Zero(t).Interface(), wrapError(a, t.String(), err)
+		}
+		src = reflect.ValueOf(m)
+	case nil:
+		return reflect.Zero(t).Interface(), nil
+	default:
+		src = reflect.ValueOf(a)
+		if src.Kind() != reflect.Map {
+			return reflect.Zero(t).Interface(), newCastError(a, t.String(), ErrUnsupported, nil)
+		}
+	}
+
+	m := reflect.MakeMapWithSize(t, src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		key := iter.Key().Interface()
+		k, err := castKey(key)
+		if err != nil {
+			return reflect.Zero(t).Interface(), keyError(a, t.String(), key, err)
+		}
+		v, err := castValue(iter.Value().Interface())
+		if err != nil {
+			return reflect.Zero(t).Interface(), keyError(a, t.String(), key, err)
+		}
+		m.SetMapIndex(reflect.ValueOf(k), valueOf(v, t.Elem()))
+	}
+	return m.Interface(), nil
+}
+
+// valueOf returns the reflect.Value of v, or the zero value of t when v is a
+// nil interface.
+func valueOf(v any, t reflect.Type) reflect.Value {
+	if v == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(v)
+}
+
+// keyError reports that the entry of a with the given key failed to cast, with
+// the kind of the entry's error.
+func keyError(a any, to string, key any, err error) error {
+	return newCastError(a, to, errorKind(err), &KeyError{Key: key, Err: err})
+}
+
+// decodeJSONObject decodes b as a JSON object, keeping numbers as json.Number
+// so that no precision is lost before the values are cast.
+func decodeJSONObject(b []byte) (map[string]any, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var m map[string]any
+	if err := d.Decode(&m); err != nil {
+		return nil, err
+	}
+	if d.More() {
+		return nil, ErrSyntax
+	}
+	return m, nil
+}
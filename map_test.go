@@ -0,0 +1,66 @@
+package cast_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+func TestToStringMapE(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, map[any]any{"a": 1, 2: "b"}, map[string]any{"a": 1, "2": "b"}, false},
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, map[string]any{"a": 1}, map[string]any{"a": 1}, false},
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, `{"a": 1, "b": "x"}`, map[string]any{"a": json.Number("1"), "b": "x"}, false},
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, nil, map[string]any(nil), false},
+		{func(v any) (any, error) { return cast.ToStringMapStringE(v) }, map[int]float64{1: 2.5}, map[string]string{"1": "2.5"}, false},
+		{func(v any) (any, error) { return cast.ToStringMapStringE(v) }, []byte(`{"a": 1}`), map[string]string{"a": "1"}, false},
+		{func(v any) (any, error) { return cast.ToStringMapIntE(v) }, map[any]any{"a": "8", "b": 2.5}, map[string]int{"a": 8, "b": 2}, false},
+		{func(v any) (any, error) { return cast.ToStringMapIntE(v) }, `{"id": 9007199254740993}`, map[string]int{"id": 9007199254740993}, false},
+		{func(v any) (any, error) { return cast.ToStringMapBoolE(v) }, &map[string]string{"a": "true", "b": "0"}, map[string]bool{"a": true, "b": false}, false},
+		{func(v any) (any, error) { return cast.ToStringMapStringSliceE(v) }, map[any]any{"a": []any{1, "b"}, "c": "d,e"}, map[string][]string{"a": {"1", "b"}, "c": {"d", "e"}}, false},
+		{func(v any) (any, error) { return cast.To[map[myString]myInt](v) }, map[any]any{"a": "1"}, map[myString]myInt{"a": 1}, false},
+		// errors
+		{func(v any) (any, error) { return cast.ToStringMapIntE(v) }, map[string]any{"a": "x"}, map[string]int(nil), true},
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, map[any]any{struct{}{}: 1}, map[string]any(nil), true},
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, `{"a": `, map[string]any(nil), true},
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, `[1, 2]`, map[string]any(nil), true},
+		{func(v any) (any, error) { return cast.ToStringMapE(v) }, 8, map[string]any(nil), true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, DeepEquals, test.expect, errmsg)
+	}
+}
+
+func TestToStringMapEKey(t *testing.T) {
+	c := New(t)
+
+	_, err := cast.ToStringMapIntE(map[string]any{"a": 1, "b": "x"})
+	var ke *cast.KeyError
+	c.Assert(errors.As(err, &ke), IsTrue)
+	c.Assert(ke.Key, Equals, any("b"))
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast .* to map\[string\]int: key "b": unable to cast "x" of type string to int: strconv.ParseInt: parsing "x": invalid syntax`)
+
+	_, err = cast.ToStringMapBoolE(`{"a": "maybe"}`)
+	c.Assert(errors.As(err, &ke), IsTrue)
+	c.Assert(ke.Key, Equals, any("a"))
+}
@@ -0,0 +1,105 @@
+package cast
+
+import (
+	"math/big"
+	"strings"
+)
+
+// ProportionMode is an option of New selecting whether the strings cast to
+// floats, *big.Float, *big.Rat and decimal.Decimal may be proportions.
+type ProportionMode int
+
+const (
+	// NoProportions accepts only the numbers of the grammar documented in
+	// lexer.go. It is the default.
+	NoProportions ProportionMode = iota
+	// Proportions also accepts percentages such as "12.5%", per-mille
+	// values such as "3‰", basis points such as "25bp" and ratios such as
+	// "1:4", which are 0.125, 0.003, 0.0025 and 0.25. ToBigRatE returns
+	// their exact value. The casts to integer types, bool and complex types
+	// do not accept them.
+	Proportions
+)
+
+// proportionUnits holds the suffixes of proportions, in lower case, with
+// their values.
+var proportionUnits = []struct {
+	suffix string
+	value  *big.Rat
+}{
+	{"%", big.NewRat(1, 100)},
+	{"‰", big.NewRat(1, 1000)},
+	{"‱", big.NewRat(1, 10000)},
+	{"bps", big.NewRat(1, 10000)},
+	{"bp", big.NewRat(1, 10000)},
+}
+
+// withoutProportions returns c, or a copy of c that does not accept
+// proportions when it does, for the casts to integers.
+func (c *Caster) withoutProportions() *Caster {
+	if !c.dec.proportions {
+		return c
+	}
+	d := *c
+	d.dec.proportions = false
+	return &d
+}
+
+// parseReal returns the value of s like parse, also accepting proportions
+// when p does.
+func (p decimalParser) parseReal(s string) (number, error) {
+	if p.proportions {
+		if r, ok, err := p.proportion(s); ok {
+			return number{rat: r}, err
+		}
+	}
+	return p.parse(s)
+}
+
+// proportion returns the value of the proportion s. The second result
+// reports whether s has the shape of one.
+func (p decimalParser) proportion(s string) (*big.Rat, bool, error) {
+	if num, denom, ok := strings.Cut(s, ":"); ok {
+		n, err := p.exactReal(num)
+		if err != nil {
+			return nil, true, err
+		}
+		d, err := p.exactReal(denom)
+		if err != nil {
+			return nil, true, err
+		}
+		if d.Sign() == 0 {
+			return nil, true, ErrNotFinite
+		}
+		return n.Quo(n, d), true, nil
+	}
+
+	lower := strings.ToLower(s)
+	for _, unit := range proportionUnits {
+		if !strings.HasSuffix(lower, unit.suffix) {
+			continue
+		}
+		r, err := p.exactReal(strings.TrimSpace(s[:len(s)-len(unit.suffix)]))
+		if err != nil {
+			return nil, true, err
+		}
+		return r.Mul(r, unit.value), true, nil
+	}
+	return nil, false, nil
+}
+
+// exactReal returns the exact value of s, a real number of the grammar.
+func (p decimalParser) exactReal(s string) (*big.Rat, error) {
+	n, err := p.parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if n.imag != 0 {
+		return nil, ErrSyntax
+	}
+	r, err := n.bigRat()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Set(r), nil
+}
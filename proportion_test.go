@@ -0,0 +1,113 @@
+package cast_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
+)
+
+func TestProportions(t *testing.T) {
+	c := New(t)
+
+	caster, err := cast.New(cast.Proportions)
+	c.Assert(err, IsNil)
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		kind   cast.ErrorKind
+	}{
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "12.5%", 0.125, 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "12.5 %", 0.125, 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "-50%", -0.5, 0},
+		{func(v any) (any, error) { return caster.ToFloat32E(v) }, "3‰", float32(0.003), 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "25bp", 0.0025, 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "25 BPS", 0.0025, 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "1‱", 0.0001, 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "1:4", 0.25, 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "3/8", 0.375, 0},
+		{func(v any) (any, error) { return caster.ToBigRatE(v) }, "12.5%", big.NewRat(1, 8), 0},
+		{func(v any) (any, error) { return caster.ToBigRatE(v) }, "1:3", big.NewRat(1, 3), 0},
+		{func(v any) (any, error) { return caster.ToBigRatE(v) }, "1.5:0.5", big.NewRat(3, 1), 0},
+		{func(v any) (any, error) { return caster.ToBigRatE(v) }, "1/3%", big.NewRat(1, 300), 0},
+		{func(v any) (any, error) { return caster.ToBigRatE(v) }, "0x10%", big.NewRat(4, 25), 0},
+		{func(v any) (any, error) { return caster.ToBigFloatE(v) }, "50%", big.NewFloat(0.5), 0},
+		{func(v any) (any, error) { return caster.ToDecimalE(v) }, "0.01bp", decimal.RequireFromString("0.000001"), 0},
+		{func(v any) (any, error) { return caster.ToDecimalE(v) }, []byte("3:4"), decimal.RequireFromString("0.75"), 0},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "1:0", 0.0, cast.ErrNotFinite},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "inf%", 0.0, cast.ErrNotFinite},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "%", 0.0, cast.ErrSyntax},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "1:2:3", 0.0, cast.ErrSyntax},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "1+2i%", 0.0, cast.ErrSyntax},
+		{func(v any) (any, error) { return caster.ToFloat64E(v) }, "5%%", 0.0, cast.ErrSyntax},
+		// Only the casts to floats and rationals accept proportions.
+		{func(v any) (any, error) { return caster.ToIntE(v) }, "50%", 0, cast.ErrSyntax},
+		{func(v any) (any, error) { return caster.ToBigIntE(v) }, "1:1", big.NewInt(0), cast.ErrSyntax},
+		{func(v any) (any, error) { return caster.ToBoolE(v) }, "50%", false, cast.ErrSyntax},
+		// They are opt-in.
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, "12.5%", 0.0, cast.ErrSyntax},
+		{func(v any) (any, error) { return cast.ToBigRatE(v) }, "1:4", big.NewRat(0, 1), cast.ErrSyntax},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.kind != 0 {
+			c.Assert(errors.Is(err, test.kind), IsTrue, Commentf("i = %d, input = %#v, err = %v", i, test.input, err))
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(fmt.Sprint(v), Equals, fmt.Sprint(test.expect), errmsg)
+	}
+
+	strict, err := cast.New(cast.Proportions, cast.Strict)
+	c.Assert(err, IsNil)
+	f, err := strict.ToFloat64E("0.1%")
+	c.Assert(err, IsNil)
+	c.Assert(f, Equals, 0.001)
+	_, err = strict.ToFloat64E("1:3")
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+	_, err = strict.ToDecimalE("2:3")
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+
+	de, _ := cast.LookupLocale("de-DE")
+	german, err := cast.New(de, cast.Proportions)
+	c.Assert(err, IsNil)
+	r, err := german.ToBigRatE("12,5 %")
+	c.Assert(err, IsNil)
+	c.Assert(r.String(), Equals, "1/8")
+
+	_, err = cast.New(cast.ProportionMode(2))
+	c.Assert(err, ErrorMatches, `unsupported option .*`)
+}
+
+func TestProportionsIntegers(t *testing.T) {
+	c := New(t)
+
+	// The casts to integers and byte sizes reject proportions whatever the
+	// rounding.
+	for _, args := range [][]any{{cast.Proportions}, {cast.Proportions, cast.RoundHalfUp}, {cast.Proportions, cast.RoundCeil}} {
+		caster, err := cast.New(args...)
+		c.Assert(err, IsNil)
+		for _, input := range []string{"150%", "3‰", "25bp", "3:2", "50%"} {
+			errmsg := Commentf("args = %v, input = %q", args, input)
+
+			_, err = caster.ToIntE(input)
+			c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue, errmsg)
+			_, err = caster.ToUint64E(input)
+			c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue, errmsg)
+			_, err = caster.ToBigIntE(input)
+			c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue, errmsg)
+			_, err = caster.ToByteSizeE(input)
+			c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue, errmsg)
+			_, err = caster.ToBigByteSizeE(input)
+			c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue, errmsg)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package cast
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// A Conversion converts values of the type From to the type To with a
+// function supplied by the user, teaching the casts about types such as
+// uuid.UUID, net.IP or a Money type. Conversions are registered for every
+// Caster with Register, or passed to New as options for a single Caster, whose
+// conversions then take precedence.
+//
+// A conversion from the very type of the value, or of the value it points to,
+// to the target type is used before the casts of the package, so that it also
+// applies to values they would read as text or as their underlying type, such
+// as a net.IP or a fmt.Stringer. The other conversions are consulted when the
+// casts do not support the value or the target type. A conversion from the
+// type of the value, or from an interface it implements, to the target type is
+// used first. Failing that, a
+// conversion from the type of the value to another type is used and its result
+// cast to the target type, or the value is cast to the source type of a
+// conversion to the target type. A pointer is dereferenced when no conversion
+// accepts it, and one is allocated when no conversion returns it.
+type Conversion struct {
+	From reflect.Type
+	To   reflect.Type
+	fn   func(any) (any, error)
+}
+
+// Converter returns the Conversion performed by f, to be passed to New.
+func Converter[T, U any](f func(T) (U, error)) Conversion {
+	return Conversion{
+		From: reflect.TypeOf((*T)(nil)).Elem(),
+		To:   reflect.TypeOf((*U)(nil)).Elem(),
+		fn:   func(a any) (any, error) { return f(a.(T)) },
+	}
+}
+
+// Convert converts a, which must be of the type From, or implement it when
+// From is an interface type.
+func (v Conversion) Convert(a any) (any, error) {
+	if v.fn == nil || !v.accepts(reflect.TypeOf(a)) {
+		return nil, newCastError(a, typeName(v.To), ErrUnsupported, nil)
+	}
+	return v.fn(a)
+}
+
+// accepts reports whether the conversion applies to values of type t.
+func (v Conversion) accepts(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	return t == v.From || v.From.Kind() == reflect.Interface && t.Implements(v.From)
+}
+
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.String()
+}
+
+// registry holds the conversions registered with Register, also indexed by
+// their source and target types, and the set of the kinds of their source
+// types, as bits, that spares most casts a lookup.
+var registry struct {
+	sync.RWMutex
+	conversions []Conversion
+	exact       map[[2]reflect.Type]Conversion
+	fromKinds   atomic.Uint32
+}
+
+// Register registers f as the conversion from T to U of every Caster,
+// replacing any previous conversion between the same types. It is typically
+// called from an init function.
+func Register[T, U any](f func(T) (U, error)) {
+	registry.Lock()
+	defer registry.Unlock()
+	v := Converter(f)
+	registry.conversions = addConversion(registry.conversions, v)
+	if registry.exact == nil {
+		registry.exact = make(map[[2]reflect.Type]Conversion)
+	}
+	registry.exact[[2]reflect.Type{v.From, v.To}] = v
+	registry.fromKinds.Store(registry.fromKinds.Load() | 1<<v.From.Kind())
+}
+
+// addConversion returns a copy of conversions with v added, in place of the
+// conversion between the same types if there is one.
+func addConversion(conversions []Conversion, v Conversion) []Conversion {
+	s := make([]Conversion, 0, len(conversions)+1)
+	for _, w := range conversions {
+		if w.From != v.From || w.To != v.To {
+			s = append(s, w)
+		}
+	}
+	return append(s, v)
+}
+
+// Conversions returns the conversions registered with Register, sorted by
+// their source and target types.
+func Conversions() []Conversion {
+	return std.Conversions()
+}
+
+// Conversions returns the conversions available to c, its own and those
+// registered with Register, sorted by their source and target types.
+func (c *Caster) Conversions() []Conversion {
+	s := append([]Conversion(nil), c.conversionList()...)
+	sort.SliceStable(s, func(i, j int) bool {
+		if s[i].From != s[j].From {
+			return s[i].From.String() < s[j].From.String()
+		}
+		return s[i].To.String() < s[j].To.String()
+	})
+	return s
+}
+
+// Lookup returns the registered conversion used to cast values of the type
+// from to the type to, if there is one.
+func Lookup(from, to reflect.Type) (Conversion, bool) {
+	return std.Lookup(from, to)
+}
+
+// Lookup returns the conversion of c used to cast values of the type from to
+// the type to, if there is one.
+func (c *Caster) Lookup(from, to reflect.Type) (Conversion, bool) {
+	return lookupConversion(c.conversionList(), from, to)
+}
+
+// conversionList returns the conversions of c followed by the registered ones
+// between other types, in the order they were added.
+func (c *Caster) conversionList() []Conversion {
+	registry.RLock()
+	global := registry.conversions
+	registry.RUnlock()
+	if len(c.conversions) == 0 {
+		return global
+	}
+
+	s := c.conversions
+	for _, v := range global {
+		s = appendMissing(s, v)
+	}
+	return s
+}
+
+// appendMissing appends v to conversions unless they have a conversion between
+// the same types.
+func appendMissing(conversions []Conversion, v Conversion) []Conversion {
+	for _, w := range conversions {
+		if w.From == v.From && w.To == v.To {
+			return conversions
+		}
+	}
+	return append(conversions[:len(conversions):len(conversions)], v)
+}
+
+// lookupConversion returns the conversion to the type to that accepts values
+// of the type from, preferring one from that very type to one from an
+// interface it implements.
+func lookupConversion(conversions []Conversion, from, to reflect.Type) (Conversion, bool) {
+	for _, v := range conversions {
+		if v.From == from && v.To == to {
+			return v, true
+		}
+	}
+	for _, v := range conversions {
+		if v.To == to && v.accepts(from) {
+			return v, true
+		}
+	}
+	return Conversion{}, false
+}
+
+// exactConversion returns the conversion of c from the type of a, or of the
+// value a points to, to the type t, with the value it applies to. The third
+// result reports whether there is one.
+func (c *Caster) exactConversion(a any, t reflect.Type) (Conversion, any, bool) {
+	if a == nil {
+		return Conversion{}, nil, false
+	}
+
+	for b := a; ; {
+		if v, ok := c.conversionBetween(reflect.TypeOf(b), t); ok {
+			return v, b, true
+		}
+		p := reflect.ValueOf(b)
+		if p.Kind() != reflect.Ptr || p.IsNil() {
+			return Conversion{}, nil, false
+		}
+		b = p.Elem().Interface()
+	}
+}
+
+// conversionBetween returns the conversion of c from the type from to the
+// type to, if there is one.
+func (c *Caster) conversionBetween(from, to reflect.Type) (Conversion, bool) {
+	for _, v := range c.conversions {
+		if v.From == from && v.To == to {
+			return v, true
+		}
+	}
+	if registry.fromKinds.Load()&(1<<from.Kind()) == 0 {
+		return Conversion{}, false
+	}
+	registry.RLock()
+	v, ok := registry.exact[[2]reflect.Type{from, to}]
+	registry.RUnlock()
+	return v, ok
+}
+
+// convert casts a to the type t with the conversions of c as described by
+// Conversion. The second result reports whether any conversion applied.
+func (c *Caster) convert(a any, t reflect.Type) (any, bool, error) {
+	conversions := c.conversionList()
+	if a == nil || len(conversions) == 0 {
+		return nil, false, nil
+	}
+
+	zero := reflect.Zero(t).Interface()
+	values := []any{a}
+	if b := indirect(a); reflect.TypeOf(b) != reflect.TypeOf(a) {
+		values = append(values, b)
+	}
+	for _, b := range values {
+		from := reflect.TypeOf(b)
+		if v, ok := lookupConversion(conversions, from, t); ok {
+			w, err := v.fn(b)
+			if err != nil {
+				return zero, true, wrapError(a, t.String(), err)
+			}
+			return w, true, nil
+		}
+
+		for _, v := range conversions {
+			if !v.accepts(from) {
+				continue
+			}
+			w, err := v.fn(b)
+			if err != nil {
+				return zero, true, wrapError(a, t.String(), err)
+			}
+			if w, err = c.castType(w, t); err == nil {
+				return w, true, nil
+			}
+		}
+
+		for _, v := range conversions {
+			if v.To != t || v.From.Kind() == reflect.Interface {
+				continue
+			}
+			w, err := c.castType(b, v.From)
+			if err != nil {
+				continue
+			}
+			if w, err = v.fn(w); err != nil {
+				return zero, true, wrapError(a, t.String(), err)
+			}
+			return w, true, nil
+		}
+	}
+
+	if t.Kind() == reflect.Ptr {
+		w, ok, err := c.convert(a, t.Elem())
+		if !ok || err != nil {
+			return zero, ok, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(valueOf(w, t.Elem()))
+		return p.Interface(), true, nil
+	}
+	return nil, false, nil
+}
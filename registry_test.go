@@ -0,0 +1,184 @@
+package cast_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
+)
+
+type money struct{ Cents int64 }
+
+type celsius struct{ degrees float64 }
+
+func init() {
+	cast.Register(func(m money) (decimal.Decimal, error) {
+		return decimal.New(m.Cents, -2), nil
+	})
+	cast.Register(func(ip net.IP) (uint32, error) {
+		ip4 := ip.To4()
+		if ip4 == nil {
+			return 0, fmt.Errorf("%v is not an IPv4 address", ip)
+		}
+		return binary.BigEndian.Uint32(ip4), nil
+	})
+	cast.Register(func(s string) (money, error) {
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return money{}, err
+		}
+		return money{d.Shift(2).IntPart()}, nil
+	})
+}
+
+func TestRegister(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, money{1234}, 12.34, false},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, money{1234}, "12.34", false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, &money{1234}, 12, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, []money{{100}, {250}}, []int{1, 2}, false},
+		{func(v any) (any, error) { return cast.To[money](v) }, "12.34", money{1234}, false},
+		{func(v any) (any, error) { return cast.To[money](v) }, 12, money{1200}, false},
+		{func(v any) (any, error) { return cast.To[*money](v) }, "1.5", &money{150}, false},
+		{func(v any) (any, error) { return cast.To[money](v) }, "twelve", money{}, true},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, celsius{21.5}, 0.0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, Not(IsNil), errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, DeepEquals, test.expect, errmsg)
+	}
+
+	// Conversions from the type of the value come before the casts of the
+	// package, which would read a net.IP as bytes.
+	ip := net.ParseIP("1.2.3.4")
+	n, err := cast.ToUint32E(ip)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, uint32(0x01020304))
+	n, err = cast.To[uint32](&ip)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, uint32(0x01020304))
+	_, err = cast.ToUint32E(net.ParseIP("::1"))
+	c.Assert(err, ErrorMatches, `unable to cast .* to uint32: ::1 is not an IPv4 address`)
+	_, err = cast.ToUint64E(ip)
+	c.Assert(err, Not(IsNil))
+
+	_, err = cast.To[money]("twelve")
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast "twelve" of type string to cast_test.money: .*`)
+
+	var out struct {
+		Price money `cast:"price"`
+	}
+	c.Assert(cast.Decode(`{"price": "9.99"}`, &out), IsNil)
+	c.Assert(out.Price, Equals, money{999})
+}
+
+func TestCasterConversions(t *testing.T) {
+	c := New(t)
+
+	toFloat := cast.Converter(func(v celsius) (float64, error) { return v.degrees, nil })
+	fromStringer := cast.Converter(func(v fmt.Stringer) (celsius, error) {
+		f, err := cast.ToFloat64E(strings.TrimSuffix(v.String(), "°C"))
+		return celsius{f}, err
+	})
+	caster, err := cast.New(toFloat, fromStringer)
+	c.Assert(err, IsNil)
+
+	f, err := caster.ToFloat64E(celsius{21.5})
+	c.Assert(err, IsNil)
+	c.Assert(f, Equals, 21.5)
+	n, err := caster.ToIntE(celsius{21.5})
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 21)
+	v, err := cast.To[celsius](stringer("-3°C"), caster)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, celsius{-3})
+
+	_, err = cast.ToFloat64E(celsius{21.5})
+	c.Assert(errors.Is(err, cast.ErrUnsupported), IsTrue)
+
+	// An exact conversion wins over the text of a Stringer and over the
+	// dedicated casts of time.Time.
+	exact, err := cast.New(
+		cast.Converter(func(v stringer) (int64, error) { return int64(len(v)), nil }),
+		cast.Converter(func(v time.Time) (int64, error) { return v.UnixMilli(), nil }),
+	)
+	c.Assert(err, IsNil)
+	i64, err := exact.ToInt64E(stringer("twelve"))
+	c.Assert(err, IsNil)
+	c.Assert(i64, Equals, int64(6))
+	i64, err = cast.To[int64](time.UnixMilli(1500), exact)
+	c.Assert(err, IsNil)
+	c.Assert(i64, Equals, int64(1500))
+	i64, err = exact.ToInt64E(time.UnixMilli(1500))
+	c.Assert(err, IsNil)
+	c.Assert(i64, Equals, int64(1500))
+	i, err := exact.ToIntE(stringer("12"))
+	c.Assert(err, IsNil)
+	c.Assert(i, Equals, 12)
+
+	celsiusType, floatType := reflect.TypeOf(celsius{}), reflect.TypeOf(0.0)
+	conv, ok := caster.Lookup(celsiusType, floatType)
+	c.Assert(ok, IsTrue)
+	c.Assert(conv.From, Equals, celsiusType)
+	c.Assert(conv.To, Equals, floatType)
+	r, err := conv.Convert(celsius{4})
+	c.Assert(err, IsNil)
+	c.Assert(r, Equals, 4.0)
+	_, err = conv.Convert("4")
+	c.Assert(errors.Is(err, cast.ErrUnsupported), IsTrue)
+
+	_, ok = caster.Lookup(reflect.TypeOf(stringer("")), celsiusType)
+	c.Assert(ok, IsTrue)
+	_, ok = cast.Lookup(celsiusType, floatType)
+	c.Assert(ok, IsFalse)
+	_, ok = cast.Lookup(reflect.TypeOf(money{}), reflect.TypeOf(decimal.Decimal{}))
+	c.Assert(ok, IsTrue)
+
+	c.Assert(conversionNames(caster.Conversions()), DeepEquals, []string{
+		"cast_test.celsius -> float64",
+		"cast_test.money -> decimal.Decimal",
+		"fmt.Stringer -> cast_test.celsius",
+		"net.IP -> uint32",
+		"string -> cast_test.money",
+	})
+	c.Assert(conversionNames(cast.Conversions()), DeepEquals, []string{
+		"cast_test.money -> decimal.Decimal",
+		"net.IP -> uint32",
+		"string -> cast_test.money",
+	})
+
+	_, err = cast.New(cast.Conversion{})
+	c.Assert(err, ErrorMatches, `unsupported option .*`)
+}
+
+func conversionNames(conversions []cast.Conversion) []string {
+	names := make([]string, len(conversions))
+	for i, v := range conversions {
+		names[i] = v.From.String() + " -> " + v.To.String()
+	}
+	return names
+}
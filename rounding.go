@@ -0,0 +1,89 @@
+package cast
+
+import (
+	"math/big"
+	"reflect"
+)
+
+// RoundingMode is an option of New, To and Decode selecting how a number with a
+// fractional part is rounded when it is cast to an integer type.
+type RoundingMode int
+
+const (
+	// RoundTruncate rounds toward zero, as the ToXxxE functions do. It is
+	// the default.
+	RoundTruncate RoundingMode = iota
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+	// RoundHalfUp rounds to the nearest integer, and halfway values toward
+	// positive infinity: 2.5 is 3 and -2.5 is -2.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest integer, and halfway values to the
+	// even one: 2.5 is 2 and 3.5 is 4. It is also known as banker's rounding.
+	RoundHalfEven
+	// RoundHalfAwayFromZero rounds to the nearest integer, and halfway values
+	// away from zero: 2.5 is 3 and -2.5 is -3.
+	RoundHalfAwayFromZero
+)
+
+// isIntegerType reports whether t is an integer type, whose casts truncate.
+func isIntegerType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return t == reflect.TypeOf((*big.Int)(nil))
+}
+
+// roundInput returns a rounded to an integer with the RoundingMode of c when
+// it is a finite number with a fractional part, such as 8.7, big.NewRat(17, 2)
+// or "8.5", and a unchanged otherwise. The second result reports whether a was
+// rounded. Only the real part of a complex number is kept. Proportions are
+// left to the cast, which rejects them. A negative a that rounds to zero is
+// also returned unchanged, so that unsigned casts reject it as they reject
+// every negative number.
+func (c *Caster) roundInput(a any) (any, bool) {
+	r, err := c.withoutProportions().toBigRatE(a)
+	if err != nil || r.IsInt() {
+		return a, false
+	}
+	n := roundRat(r, c.rounding)
+	if n.Sign() == 0 && r.Sign() < 0 {
+		return a, false
+	}
+	return n, true
+}
+
+// roundRat rounds r to an integer with mode.
+func roundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+
+	// Compare the remainder to half of the denominator.
+	half := new(big.Int).Abs(m)
+	half.Lsh(half, 1)
+	c := half.Cmp(r.Denom())
+
+	var away bool
+	switch mode {
+	case RoundFloor:
+		away = m.Sign() < 0
+	case RoundCeil:
+		away = m.Sign() > 0
+	case RoundHalfUp:
+		away = c > 0 || c == 0 && m.Sign() > 0
+	case RoundHalfEven:
+		away = c > 0 || c == 0 && q.Bit(0) == 1
+	case RoundHalfAwayFromZero:
+		away = c >= 0
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(m.Sign())))
+	}
+	return q
+}
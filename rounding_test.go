@@ -0,0 +1,93 @@
+package cast_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundingMode(t *testing.T) {
+	c := New(t)
+
+	inputs := []any{2.5, -2.5, 3.5, 2.7, -2.7, 2.2, -2.2, 3.0}
+	tests := []struct {
+		mode   cast.RoundingMode
+		expect []int
+	}{
+		{cast.RoundTruncate, []int{2, -2, 3, 2, -2, 2, -2, 3}},
+		{cast.RoundFloor, []int{2, -3, 3, 2, -3, 2, -3, 3}},
+		{cast.RoundCeil, []int{3, -2, 4, 3, -2, 3, -2, 3}},
+		{cast.RoundHalfUp, []int{3, -2, 4, 3, -3, 2, -2, 3}},
+		{cast.RoundHalfEven, []int{2, -2, 4, 3, -3, 2, -2, 3}},
+		{cast.RoundHalfAwayFromZero, []int{3, -3, 4, 3, -3, 2, -2, 3}},
+	}
+
+	for i, test := range tests {
+		for j, input := range inputs {
+			errmsg := Commentf("i = %d, j = %d, mode = %d, input = %#v", i, j, test.mode, input)
+
+			v, err := cast.To[int](input, test.mode)
+			c.Assert(err, IsNil, errmsg)
+			c.Assert(v, Equals, test.expect[j], errmsg)
+		}
+	}
+}
+
+func TestRoundingModeInputs(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.To[int](v, cast.RoundHalfEven) }, "2.5", 2, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.RoundHalfEven) }, "7/2", 4, false},
+		{func(v any) (any, error) { return cast.To[int64](v, cast.RoundCeil) }, big.NewRat(1, 3), int64(1), false},
+		{func(v any) (any, error) { return cast.To[int32](v, cast.RoundFloor) }, big.NewFloat(-0.5), int32(-1), false},
+		{func(v any) (any, error) { return cast.To[uint](v, cast.RoundHalfUp) }, float32(1.5), uint(2), false},
+		{func(v any) (any, error) { return cast.To[uint8](v, cast.RoundHalfAwayFromZero) }, decimal.RequireFromString("9.5"), uint8(10), false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.RoundCeil) }, complex(1.5, 2), 2, false},
+		{func(v any) (any, error) { return cast.To[myInt](v, cast.RoundHalfEven) }, 0.5, myInt(0), false},
+		{func(v any) (any, error) { return cast.To[[]int](v, cast.RoundHalfEven) }, "0.5, 1.5, 2.5", []int{0, 2, 2}, false},
+		{func(v any) (any, error) { return cast.To[float64](v, cast.RoundCeil) }, 1.5, 1.5, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.RoundCeil) }, true, 1, false},
+		// errors
+		{func(v any) (any, error) { return cast.To[int8](v, cast.RoundCeil) }, 127.5, int8(0), true},
+		{func(v any) (any, error) { return cast.To[uint](v, cast.RoundFloor) }, -0.5, uint(0), true},
+		{func(v any) (any, error) { return cast.To[int](v, cast.RoundHalfEven, cast.Strict) }, 2.5, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, DeepEquals, test.expect, errmsg)
+	}
+
+	n, err := cast.To[*big.Int]("-12345678901234567890.5", cast.RoundHalfAwayFromZero)
+	c.Assert(err, IsNil)
+	c.Assert(n.String(), Equals, "-12345678901234567891")
+
+	var out struct {
+		Cents int `cast:"cents"`
+	}
+	c.Assert(cast.Decode(map[string]any{"cents": "99.5"}, &out, cast.RoundHalfEven), IsNil)
+	c.Assert(out.Cents, Equals, 100)
+
+	_, err = cast.To[int8](127.5, cast.RoundCeil)
+	var ce *cast.CastError
+	c.Assert(errors.As(err, &ce), IsTrue)
+	c.Assert(ce.Value, Equals, any(127.5))
+	c.Assert(ce.Kind, Equals, cast.ErrOverflow)
+}
@@ -0,0 +1,456 @@
+package cast
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ToIntSlice casts an interface to a []int type.
+func ToIntSlice(a any) []int {
+	v, _ := ToIntSliceE(a)
+	return v
+}
+
+// ToIntSliceE casts an interface to a []int type.
+func ToIntSliceE(a any) ([]int, error) {
+	return std.ToIntSliceE(a)
+}
+
+// ToIntSliceE casts an interface to a []int type with the settings of c.
+func (c *Caster) ToIntSliceE(a any) ([]int, error) {
+	return castTo[[]int](c, a)
+}
+
+// ToInt8Slice casts an interface to a []int8 type.
+func ToInt8Slice(a any) []int8 {
+	v, _ := ToInt8SliceE(a)
+	return v
+}
+
+// ToInt8SliceE casts an interface to a []int8 type.
+func ToInt8SliceE(a any) ([]int8, error) {
+	return std.ToInt8SliceE(a)
+}
+
+// ToInt8SliceE casts an interface to a []int8 type with the settings of c.
+func (c *Caster) ToInt8SliceE(a any) ([]int8, error) {
+	return castTo[[]int8](c, a)
+}
+
+// ToInt16Slice casts an interface to a []int16 type.
+func ToInt16Slice(a any) []int16 {
+	v, _ := ToInt16SliceE(a)
+	return v
+}
+
+// ToInt16SliceE casts an interface to a []int16 type.
+func ToInt16SliceE(a any) ([]int16, error) {
+	return std.ToInt16SliceE(a)
+}
+
+// ToInt16SliceE casts an interface to a []int16 type with the settings of c.
+func (c *Caster) ToInt16SliceE(a any) ([]int16, error) {
+	return castTo[[]int16](c, a)
+}
+
+// ToInt32Slice casts an interface to a []int32 type.
+func ToInt32Slice(a any) []int32 {
+	v, _ := ToInt32SliceE(a)
+	return v
+}
+
+// ToInt32SliceE casts an interface to a []int32 type.
+func ToInt32SliceE(a any) ([]int32, error) {
+	return std.ToInt32SliceE(a)
+}
+
+// ToInt32SliceE casts an interface to a []int32 type with the settings of c.
+func (c *Caster) ToInt32SliceE(a any) ([]int32, error) {
+	return castTo[[]int32](c, a)
+}
+
+// ToInt64Slice casts an interface to a []int64 type.
+func ToInt64Slice(a any) []int64 {
+	v, _ := ToInt64SliceE(a)
+	return v
+}
+
+// ToInt64SliceE casts an interface to a []int64 type.
+func ToInt64SliceE(a any) ([]int64, error) {
+	return std.ToInt64SliceE(a)
+}
+
+// ToInt64SliceE casts an interface to a []int64 type with the settings of c.
+func (c *Caster) ToInt64SliceE(a any) ([]int64, error) {
+	return castTo[[]int64](c, a)
+}
+
+// ToUintSlice casts an interface to a []uint type.
+func ToUintSlice(a any) []uint {
+	v, _ := ToUintSliceE(a)
+	return v
+}
+
+// ToUintSliceE casts an interface to a []uint type.
+func ToUintSliceE(a any) ([]uint, error) {
+	return std.ToUintSliceE(a)
+}
+
+// ToUintSliceE casts an interface to a []uint type with the settings of c.
+func (c *Caster) ToUintSliceE(a any) ([]uint, error) {
+	return castTo[[]uint](c, a)
+}
+
+// ToUint8Slice casts an interface to a []uint8 type.
+func ToUint8Slice(a any) []uint8 {
+	v, _ := ToUint8SliceE(a)
+	return v
+}
+
+// ToUint8SliceE casts an interface to a []uint8 type. Unlike ToBytesE, it
+// casts each element, so "1,2" is []uint8{1, 2}.
+func ToUint8SliceE(a any) ([]uint8, error) {
+	return std.ToUint8SliceE(a)
+}
+
+// ToUint8SliceE casts an interface to a []uint8 type with the settings of c.
+func (c *Caster) ToUint8SliceE(a any) ([]uint8, error) {
+	t := reflect.TypeOf([]uint8(nil))
+	if c.nils == NilError && isNil(a) {
+		return nil, newCastError(a, t.String(), ErrNil, nil)
+	}
+	v, err := toSlice(a, t, func(e any) (any, error) { return c.toType(e, t.Elem()) })
+	s, _ := v.([]uint8)
+	return s, err
+}
+
+// ToUint16Slice casts an interface to a []uint16 type.
+func ToUint16Slice(a any) []uint16 {
+	v, _ := ToUint16SliceE(a)
+	return v
+}
+
+// ToUint16SliceE casts an interface to a []uint16 type.
+func ToUint16SliceE(a any) ([]uint16, error) {
+	return std.ToUint16SliceE(a)
+}
+
+// ToUint16SliceE casts an interface to a []uint16 type with the settings of c.
+func (c *Caster) ToUint16SliceE(a any) ([]uint16, error) {
+	return castTo[[]uint16](c, a)
+}
+
+// ToUint32Slice casts an interface to a []uint32 type.
+func ToUint32Slice(a any) []uint32 {
+	v, _ := ToUint32SliceE(a)
+	return v
+}
+
+// ToUint32SliceE casts an interface to a []uint32 type.
+func ToUint32SliceE(a any) ([]uint32, error) {
+	return std.ToUint32SliceE(a)
+}
+
+// ToUint32SliceE casts an interface to a []uint32 type with the settings of c.
+func (c *Caster) ToUint32SliceE(a any) ([]uint32, error) {
+	return castTo[[]uint32](c, a)
+}
+
+// ToUint64Slice casts an interface to a []uint64 type.
+func ToUint64Slice(a any) []uint64 {
+	v, _ := ToUint64SliceE(a)
+	return v
+}
+
+// ToUint64SliceE casts an interface to a []uint64 type.
+func ToUint64SliceE(a any) ([]uint64, error) {
+	return std.ToUint64SliceE(a)
+}
+
+// ToUint64SliceE casts an interface to a []uint64 type with the settings of c.
+func (c *Caster) ToUint64SliceE(a any) ([]uint64, error) {
+	return castTo[[]uint64](c, a)
+}
+
+// ToFloat32Slice casts an interface to a []float32 type.
+func ToFloat32Slice(a any) []float32 {
+	v, _ := ToFloat32SliceE(a)
+	return v
+}
+
+// ToFloat32SliceE casts an interface to a []float32 type.
+func ToFloat32SliceE(a any) ([]float32, error) {
+	return std.ToFloat32SliceE(a)
+}
+
+// ToFloat32SliceE casts an interface to a []float32 type with the settings of c.
+func (c *Caster) ToFloat32SliceE(a any) ([]float32, error) {
+	return castTo[[]float32](c, a)
+}
+
+// ToFloat64Slice casts an interface to a []float64 type.
+func ToFloat64Slice(a any) []float64 {
+	v, _ := ToFloat64SliceE(a)
+	return v
+}
+
+// ToFloat64SliceE casts an interface to a []float64 type.
+func ToFloat64SliceE(a any) ([]float64, error) {
+	return std.ToFloat64SliceE(a)
+}
+
+// ToFloat64SliceE casts an interface to a []float64 type with the settings of c.
+func (c *Caster) ToFloat64SliceE(a any) ([]float64, error) {
+	return castTo[[]float64](c, a)
+}
+
+// ToBigIntSlice casts an interface to a []*big.Int type.
+func ToBigIntSlice(a any) []*big.Int {
+	v, _ := ToBigIntSliceE(a)
+	return v
+}
+
+// ToBigIntSliceE casts an interface to a []*big.Int type.
+func ToBigIntSliceE(a any) ([]*big.Int, error) {
+	return std.ToBigIntSliceE(a)
+}
+
+// ToBigIntSliceE casts an interface to a []*big.Int type with the settings of c.
+func (c *Caster) ToBigIntSliceE(a any) ([]*big.Int, error) {
+	return castTo[[]*big.Int](c, a)
+}
+
+// ToBigFloatSlice casts an interface to a []*big.Float type.
+func ToBigFloatSlice(a any) []*big.Float {
+	v, _ := ToBigFloatSliceE(a)
+	return v
+}
+
+// ToBigFloatSliceE casts an interface to a []*big.Float type.
+func ToBigFloatSliceE(a any) ([]*big.Float, error) {
+	return std.ToBigFloatSliceE(a)
+}
+
+// ToBigFloatSliceE casts an interface to a []*big.Float type with the settings of c.
+func (c *Caster) ToBigFloatSliceE(a any) ([]*big.Float, error) {
+	return castTo[[]*big.Float](c, a)
+}
+
+// ToBigRatSlice casts an interface to a []*big.Rat type.
+func ToBigRatSlice(a any) []*big.Rat {
+	v, _ := ToBigRatSliceE(a)
+	return v
+}
+
+// ToBigRatSliceE casts an interface to a []*big.Rat type.
+func ToBigRatSliceE(a any) ([]*big.Rat, error) {
+	return std.ToBigRatSliceE(a)
+}
+
+// ToBigRatSliceE casts an interface to a []*big.Rat type with the settings of c.
+func (c *Caster) ToBigRatSliceE(a any) ([]*big.Rat, error) {
+	return castTo[[]*big.Rat](c, a)
+}
+
+// ToDecimalSlice casts an interface to a []decimal.Decimal type.
+func ToDecimalSlice(a any) []decimal.Decimal {
+	v, _ := ToDecimalSliceE(a)
+	return v
+}
+
+// ToDecimalSliceE casts an interface to a []decimal.Decimal type.
+func ToDecimalSliceE(a any) ([]decimal.Decimal, error) {
+	return std.ToDecimalSliceE(a)
+}
+
+// ToDecimalSliceE casts an interface to a []decimal.Decimal type with the settings of c.
+func (c *Caster) ToDecimalSliceE(a any) ([]decimal.Decimal, error) {
+	return castTo[[]decimal.Decimal](c, a)
+}
+
+// ToComplex64Slice casts an interface to a []complex64 type.
+func ToComplex64Slice(a any) []complex64 {
+	v, _ := ToComplex64SliceE(a)
+	return v
+}
+
+// ToComplex64SliceE casts an interface to a []complex64 type.
+func ToComplex64SliceE(a any) ([]complex64, error) {
+	return std.ToComplex64SliceE(a)
+}
+
+// ToComplex64SliceE casts an interface to a []complex64 type with the settings of c.
+func (c *Caster) ToComplex64SliceE(a any) ([]complex64, error) {
+	return castTo[[]complex64](c, a)
+}
+
+// ToComplex128Slice casts an interface to a []complex128 type.
+func ToComplex128Slice(a any) []complex128 {
+	v, _ := ToComplex128SliceE(a)
+	return v
+}
+
+// ToComplex128SliceE casts an interface to a []complex128 type.
+func ToComplex128SliceE(a any) ([]complex128, error) {
+	return std.ToComplex128SliceE(a)
+}
+
+// ToComplex128SliceE casts an interface to a []complex128 type with the settings of c.
+func (c *Caster) ToComplex128SliceE(a any) ([]complex128, error) {
+	return castTo[[]complex128](c, a)
+}
+
+// ToBoolSlice casts an interface to a []bool type.
+func ToBoolSlice(a any) []bool {
+	v, _ := ToBoolSliceE(a)
+	return v
+}
+
+// ToBoolSliceE casts an interface to a []bool type.
+func ToBoolSliceE(a any) ([]bool, error) {
+	return std.ToBoolSliceE(a)
+}
+
+// ToBoolSliceE casts an interface to a []bool type with the settings of c.
+func (c *Caster) ToBoolSliceE(a any) ([]bool, error) {
+	return castTo[[]bool](c, a)
+}
+
+// ToStringSlice casts an interface to a []string type.
+func ToStringSlice(a any) []string {
+	v, _ := ToStringSliceE(a)
+	return v
+}
+
+// ToStringSliceE casts an interface to a []string type.
+func ToStringSliceE(a any) ([]string, error) {
+	return std.ToStringSliceE(a)
+}
+
+// ToStringSliceE casts an interface to a []string type with the settings of c.
+func (c *Caster) ToStringSliceE(a any) ([]string, error) {
+	return castTo[[]string](c, a)
+}
+
+// ToTimeSlice casts an interface to a []time.Time type.
+func ToTimeSlice(a any, args ...any) []time.Time {
+	v, _ := ToTimeSliceE(a, args...)
+	return v
+}
+
+// ToTimeSliceE casts an interface to a []time.Time type. The options are
+// those of ToTimeE and apply to every element.
+func ToTimeSliceE(a any, args ...any) ([]time.Time, error) {
+	return std.ToTimeSliceE(a, args...)
+}
+
+// ToTimeSliceE casts an interface to a []time.Time type with the settings of
+// c. The options are those of ToTimeE and apply to every element.
+func (c *Caster) ToTimeSliceE(a any, args ...any) ([]time.Time, error) {
+	c, err := c.withTimeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return castTo[[]time.Time](c, a)
+}
+
+// ToDurationSlice casts an interface to a []time.Duration type.
+func ToDurationSlice(a any, args ...any) []time.Duration {
+	v, _ := ToDurationSliceE(a, args...)
+	return v
+}
+
+// ToDurationSliceE casts an interface to a []time.Duration type. The options
+// are those of ToDurationE and apply to every element.
+func ToDurationSliceE(a any, args ...any) ([]time.Duration, error) {
+	return std.ToDurationSliceE(a, args...)
+}
+
+// ToDurationSliceE casts an interface to a []time.Duration type with the
+// settings of c. The options are those of ToDurationE and apply to every
+// element.
+func (c *Caster) ToDurationSliceE(a any, args ...any) ([]time.Duration, error) {
+	c, err := c.withDurationArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return castTo[[]time.Duration](c, a)
+}
+
+// toSlice casts an interface to the slice type t, casting each element with
+// cast. The elements of a slice or an array are cast in order. A string or
+// []byte is split into elements by splitList. A nil value is a nil slice, and
+// any other value is the only element of the result.
+func toSlice(a any, t reflect.Type, cast func(any) (any, error)) (any, error) {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		v, err := toSlice(b, t, cast)
+		return v, wrapError(a, t.String(), err)
+	}
+
+	var (
+		elems  []any
+		scalar bool
+	)
+	switch v := a.(type) {
+	case string:
+		elems = splitList(v)
+	case []byte:
+		elems = splitList(string(v))
+	case nil:
+		return reflect.Zero(t).Interface(), nil
+	default:
+		rv := reflect.ValueOf(a)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			elems = make([]any, rv.Len())
+			for i := range elems {
+				elems[i] = rv.Index(i).Interface()
+			}
+		default:
+			elems, scalar = []any{a}, true
+		}
+	}
+
+	s := reflect.MakeSlice(t, len(elems), len(elems))
+	for i, e := range elems {
+		v, err := cast(e)
+		switch {
+		case err != nil && scalar:
+			return reflect.Zero(t).Interface(), wrapError(a, t.String(), err)
+		case err != nil:
+			return reflect.Zero(t).Interface(), indexError(a, t.String(), i, err)
+		case v != nil:
+			s.Index(i).Set(reflect.ValueOf(v))
+		}
+	}
+	return s.Interface(), nil
+}
+
+// indexError reports that the element at index i of a failed to cast, with the
+// kind of the element's error.
+func indexError(a any, to string, i int, err error) error {
+	return newCastError(a, to, errorKind(err), &IndexError{Index: i, Err: err})
+}
+
+// splitList splits a delimited string into its elements. The elements are
+// separated by commas when s has any, and by white space otherwise; the space
+// around each element is trimmed. A blank string has no elements.
+func splitList(s string) []any {
+	var fields []string
+	if strings.Contains(s, ",") {
+		fields = strings.Split(s, ",")
+	} else {
+		fields = strings.Fields(s)
+	}
+
+	elems := make([]any, len(fields))
+	for i, f := range fields {
+		elems[i] = strings.TrimSpace(f)
+	}
+	return elems
+}
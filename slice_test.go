@@ -0,0 +1,85 @@
+package cast_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+func TestToSliceE(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, []any{1, "2", 3.5, true}, []int{1, 2, 3, 1}, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, [3]int8{1, 2, 3}, []int{1, 2, 3}, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, "1, 2, 0x3", []int{1, 2, 3}, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, "1 2 3", []int{1, 2, 3}, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, 8, []int{8}, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, "", []int{}, false},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, nil, []int(nil), false},
+		{func(v any) (any, error) { return cast.ToInt64SliceE(v) }, &[]string{"8"}, []int64{8}, false},
+		{func(v any) (any, error) { return cast.ToUintSliceE(v) }, []int{1, 2}, []uint{1, 2}, false},
+		{func(v any) (any, error) { return cast.ToUint8SliceE(v) }, "1,2", []uint8{1, 2}, false},
+		{func(v any) (any, error) { return cast.ToFloat64SliceE(v) }, []any{"1.5", 2, "inf"}, []float64{1.5, 2, math.Inf(1)}, false},
+		{func(v any) (any, error) { return cast.ToBoolSliceE(v) }, "true,0,f", []bool{true, false, false}, false},
+		{func(v any) (any, error) { return cast.ToStringSliceE(v) }, []any{1, "a", 2.5}, []string{"1", "a", "2.5"}, false},
+		{func(v any) (any, error) { return cast.ToStringSliceE(v) }, "a, b ,c", []string{"a", "b", "c"}, false},
+		{func(v any) (any, error) { return cast.ToStringSliceE(v) }, []byte("a b"), []string{"a", "b"}, false},
+		{func(v any) (any, error) { return cast.ToDurationSliceE(v) }, "1s,2m", []time.Duration{time.Second, 2 * time.Minute}, false},
+		{func(v any) (any, error) { return cast.ToComplex128SliceE(v) }, "(1+2i) 3", []complex128{1 + 2i, 3}, false},
+		{func(v any) (any, error) { return cast.To[[]myInt](v) }, []string{"1", "2"}, []myInt{1, 2}, false},
+		{func(v any) (any, error) { return cast.To[[][]int](v) }, []any{"1,2", []int{3}}, [][]int{{1, 2}, {3}}, false},
+		// errors
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, []any{1, "x"}, []int(nil), true},
+		{func(v any) (any, error) { return cast.ToUintSliceE(v) }, "1,-2", []uint(nil), true},
+		{func(v any) (any, error) { return cast.ToIntSliceE(v) }, map[string]int{}, []int(nil), true},
+		{func(v any) (any, error) { return cast.ToInt8SliceE(v) }, 300, []int8(nil), true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, DeepEquals, test.expect, errmsg)
+	}
+}
+
+func TestToSliceEIndex(t *testing.T) {
+	c := New(t)
+
+	_, err := cast.ToIntSliceE([]any{1, 2, "x"})
+	var ie *cast.IndexError
+	c.Assert(errors.As(err, &ie), IsTrue)
+	c.Assert(ie.Index, Equals, 2)
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+	c.Assert(err, ErrorMatches, `unable to cast \[\]interface {}{1, 2, "x"} of type \[\]interface {} to \[\]int: index 2: unable to cast "x" of type string to int: strconv.ParseInt: parsing "x": invalid syntax`)
+
+	_, err = cast.ToInt8SliceE("1,300")
+	c.Assert(errors.As(err, &ie), IsTrue)
+	c.Assert(ie.Index, Equals, 1)
+	c.Assert(errors.Is(err, cast.ErrOverflow), IsTrue)
+
+	n, err := cast.ToBigIntSliceE([]string{"12345678901234567890123"})
+	c.Assert(err, IsNil)
+	c.Assert(n[0].String(), Equals, "12345678901234567890123")
+
+	r := cast.ToBigRatSlice("1/3, 0.5")
+	c.Assert(r, HasLen, 2)
+	c.Assert(r[0].Cmp(big.NewRat(1, 3)), Equals, 0)
+	c.Assert(r[1].Cmp(big.NewRat(1, 2)), Equals, 0)
+}
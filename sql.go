@@ -0,0 +1,230 @@
+package cast
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+// The Null types of database/sql, such as sql.NullInt64 or sql.Null[T], are
+// cast to by casting the value to the type of their first field and setting
+// Valid. A nil value or nil pointer gives a Null value that is not Valid,
+// whatever the NilMode. Any struct type made of a value and a Valid bool
+// whose pointer implements sql.Scanner is cast to the same way.
+//
+// As values, the Null types are cast through their Value method, so an
+// invalid one is cast like nil.
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// isNullType reports whether t is a Null type as described above.
+func isNullType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.NumField() == 2 &&
+		t.Field(0).IsExported() &&
+		t.Field(1).Name == "Valid" && t.Field(1).Type.Kind() == reflect.Bool &&
+		reflect.PointerTo(t).Implements(scannerType)
+}
+
+// toNull casts an interface to the Null type t.
+func (c *Caster) toNull(a any, t reflect.Type) (any, error) {
+	n := reflect.New(t).Elem()
+	if isNil(a) {
+		return n.Interface(), nil
+	}
+
+	v, err := c.toType(a, t.Field(0).Type)
+	if err != nil {
+		return n.Interface(), wrapError(a, t.String(), err)
+	}
+	n.Field(0).Set(valueOf(v, t.Field(0).Type))
+	n.Field(1).SetBool(true)
+	return n.Interface(), nil
+}
+
+// ToNullBool casts an interface to a sql.NullBool type.
+func ToNullBool(a any) sql.NullBool {
+	v, _ := ToNullBoolE(a)
+	return v
+}
+
+// ToNullBoolE casts an interface to a sql.NullBool type.
+func ToNullBoolE(a any) (sql.NullBool, error) {
+	return std.ToNullBoolE(a)
+}
+
+// ToNullBoolE casts an interface to a sql.NullBool type with the settings of
+// c.
+func (c *Caster) ToNullBoolE(a any) (sql.NullBool, error) {
+	return castTo[sql.NullBool](c, a)
+}
+
+// ToNullByte casts an interface to a sql.NullByte type.
+func ToNullByte(a any) sql.NullByte {
+	v, _ := ToNullByteE(a)
+	return v
+}
+
+// ToNullByteE casts an interface to a sql.NullByte type.
+func ToNullByteE(a any) (sql.NullByte, error) {
+	return std.ToNullByteE(a)
+}
+
+// ToNullByteE casts an interface to a sql.NullByte type with the settings of
+// c.
+func (c *Caster) ToNullByteE(a any) (sql.NullByte, error) {
+	return castTo[sql.NullByte](c, a)
+}
+
+// ToNullFloat64 casts an interface to a sql.NullFloat64 type.
+func ToNullFloat64(a any) sql.NullFloat64 {
+	v, _ := ToNullFloat64E(a)
+	return v
+}
+
+// ToNullFloat64E casts an interface to a sql.NullFloat64 type.
+func ToNullFloat64E(a any) (sql.NullFloat64, error) {
+	return std.ToNullFloat64E(a)
+}
+
+// ToNullFloat64E casts an interface to a sql.NullFloat64 type with the
+// settings of c.
+func (c *Caster) ToNullFloat64E(a any) (sql.NullFloat64, error) {
+	return castTo[sql.NullFloat64](c, a)
+}
+
+// ToNullInt16 casts an interface to a sql.NullInt16 type.
+func ToNullInt16(a any) sql.NullInt16 {
+	v, _ := ToNullInt16E(a)
+	return v
+}
+
+// ToNullInt16E casts an interface to a sql.NullInt16 type.
+func ToNullInt16E(a any) (sql.NullInt16, error) {
+	return std.ToNullInt16E(a)
+}
+
+// ToNullInt16E casts an interface to a sql.NullInt16 type with the settings
+// of c.
+func (c *Caster) ToNullInt16E(a any) (sql.NullInt16, error) {
+	return castTo[sql.NullInt16](c, a)
+}
+
+// ToNullInt32 casts an interface to a sql.NullInt32 type.
+func ToNullInt32(a any) sql.NullInt32 {
+	v, _ := ToNullInt32E(a)
+	return v
+}
+
+// ToNullInt32E casts an interface to a sql.NullInt32 type.
+func ToNullInt32E(a any) (sql.NullInt32, error) {
+	return std.ToNullInt32E(a)
+}
+
+// ToNullInt32E casts an interface to a sql.NullInt32 type with the settings
+// of c.
+func (c *Caster) ToNullInt32E(a any) (sql.NullInt32, error) {
+	return castTo[sql.NullInt32](c, a)
+}
+
+// ToNullInt64 casts an interface to a sql.NullInt64 type.
+func ToNullInt64(a any) sql.NullInt64 {
+	v, _ := ToNullInt64E(a)
+	return v
+}
+
+// ToNullInt64E casts an interface to a sql.NullInt64 type.
+func ToNullInt64E(a any) (sql.NullInt64, error) {
+	return std.ToNullInt64E(a)
+}
+
+// ToNullInt64E casts an interface to a sql.NullInt64 type with the settings
+// of c.
+func (c *Caster) ToNullInt64E(a any) (sql.NullInt64, error) {
+	return castTo[sql.NullInt64](c, a)
+}
+
+// ToNullString casts an interface to a sql.NullString type.
+func ToNullString(a any) sql.NullString {
+	v, _ := ToNullStringE(a)
+	return v
+}
+
+// ToNullStringE casts an interface to a sql.NullString type.
+func ToNullStringE(a any) (sql.NullString, error) {
+	return std.ToNullStringE(a)
+}
+
+// ToNullStringE casts an interface to a sql.NullString type with the settings
+// of c.
+func (c *Caster) ToNullStringE(a any) (sql.NullString, error) {
+	return castTo[sql.NullString](c, a)
+}
+
+// ToNullTime casts an interface to a sql.NullTime type.
+func ToNullTime(a any, args ...any) sql.NullTime {
+	v, _ := ToNullTimeE(a, args...)
+	return v
+}
+
+// ToNullTimeE casts an interface to a sql.NullTime type. The args are those of
+// ToTimeE.
+func ToNullTimeE(a any, args ...any) (sql.NullTime, error) {
+	return std.ToNullTimeE(a, args...)
+}
+
+// ToNullTimeE casts an interface to a sql.NullTime type with the settings of
+// c. The args replace the location and layouts of c.
+func (c *Caster) ToNullTimeE(a any, args ...any) (sql.NullTime, error) {
+	c, err := c.withTimeArgs(args)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return castTo[sql.NullTime](c, a)
+}
+
+// ScanInto stores a in dst, casting it with the rules of this package.
+//
+// A Null type, such as *sql.NullInt64, is set as by To. Other scanners are
+// passed a cast to one of the types of driver.Value: nil or a nil pointer is
+// passed as nil, a value of a basic kind or a driver.Valuer is converted as
+// the database/sql drivers do, and any other value, such as a *big.Rat, is
+// cast to a string.
+func ScanInto(dst sql.Scanner, a any) error {
+	return std.ScanInto(dst, a)
+}
+
+// ScanInto stores a in dst like the ScanInto function, with the settings of c.
+func (c *Caster) ScanInto(dst sql.Scanner, a any) error {
+	p := reflect.ValueOf(dst)
+	if !p.IsValid() || p.Kind() == reflect.Ptr && p.IsNil() {
+		return newCastError(a, typeName(reflect.TypeOf(dst)), ErrUnsupported, nil)
+	}
+	if t := p.Type(); t.Kind() == reflect.Ptr && isNullType(t.Elem()) {
+		v, err := c.toType(a, t.Elem())
+		if err != nil {
+			return err
+		}
+		p.Elem().Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	v, err := c.driverValue(a)
+	if err != nil {
+		return err
+	}
+	if err := dst.Scan(v); err != nil {
+		return wrapError(a, p.Type().String(), err)
+	}
+	return nil
+}
+
+// driverValue casts an interface to a driver.Value for ScanInto.
+func (c *Caster) driverValue(a any) (driver.Value, error) {
+	if isNil(a) {
+		return nil, nil
+	}
+	if v, err := driver.DefaultParameterConverter.ConvertValue(a); err == nil {
+		return v, nil
+	}
+	return c.toStringE(a)
+}
@@ -0,0 +1,156 @@
+package cast_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+// nullable has the shape of sql.Null[T].
+type nullable[T any] struct {
+	V     T
+	Valid bool
+}
+
+func (n *nullable[T]) Scan(value any) error {
+	return errors.New("not called by the casts")
+}
+
+// scanned records the value it is scanned from.
+type scanned struct{ value any }
+
+func (s *scanned) Scan(value any) error {
+	if _, ok := value.(bool); ok {
+		return fmt.Errorf("unexpected bool")
+	}
+	s.value = value
+	return nil
+}
+
+func TestNullTypes(t *testing.T) {
+	c := New(t)
+
+	ts := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.ToNullInt64E(v) }, "42", sql.NullInt64{Int64: 42, Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullInt64E(v) }, nil, sql.NullInt64{}, false},
+		{func(v any) (any, error) { return cast.ToNullInt64E(v) }, (*int)(nil), sql.NullInt64{}, false},
+		{func(v any) (any, error) { return cast.ToNullInt64E(v) }, "abc", sql.NullInt64{}, true},
+		{func(v any) (any, error) { return cast.ToNullInt32E(v) }, 1.5, sql.NullInt32{Int32: 1, Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullInt16E(v) }, 1 << 20, sql.NullInt16{}, true},
+		{func(v any) (any, error) { return cast.ToNullByteE(v) }, "255", sql.NullByte{Byte: 255, Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullFloat64E(v) }, "0.5", sql.NullFloat64{Float64: 0.5, Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullBoolE(v) }, "true", sql.NullBool{Bool: true, Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullBoolE(v) }, 0, sql.NullBool{Bool: false, Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullStringE(v) }, big.NewRat(1, 4), sql.NullString{String: "1/4", Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullStringE(v) }, sql.NullInt64{Int64: 7, Valid: true}, sql.NullString{String: "7", Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullStringE(v) }, sql.NullInt64{}, sql.NullString{}, false},
+		{func(v any) (any, error) { return cast.ToNullTimeE(v) }, "2024-01-02", sql.NullTime{Time: ts, Valid: true}, false},
+		{func(v any) (any, error) { return cast.ToNullTimeE(v) }, "", sql.NullTime{}, true},
+		{func(v any) (any, error) { return cast.To[nullable[int8]](v) }, "8", nullable[int8]{V: 8, Valid: true}, false},
+		{func(v any) (any, error) { return cast.To[*sql.NullInt64](v) }, "8", &sql.NullInt64{Int64: 8, Valid: true}, false},
+		// Null values as input
+		{func(v any) (any, error) { return cast.ToIntE(v) }, sql.NullInt64{Int64: 42, Valid: true}, 42, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, sql.NullString{String: "42", Valid: true}, 42, false},
+		{func(v any) (any, error) { return cast.ToIntE(v) }, sql.NullString{String: "42"}, 0, false},
+		{func(v any) (any, error) { return cast.ToFloat64E(v) }, &sql.NullFloat64{Float64: 0.5, Valid: true}, 0.5, false},
+		{func(v any) (any, error) { return cast.ToBoolE(v) }, sql.NullBool{Bool: true, Valid: true}, true, false},
+		{func(v any) (any, error) { return cast.ToTimeE(v) }, sql.NullTime{Time: ts, Valid: true}, ts, false},
+		{func(v any) (any, error) { return cast.ToStringE(v) }, sql.NullByte{Byte: 9, Valid: true}, "9", false},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(err, Not(IsNil), errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		c.Assert(v, DeepEquals, test.expect, errmsg)
+	}
+
+	_, err := cast.ToNullInt64E("abc")
+	c.Assert(err, ErrorMatches, `unable to cast "abc" of type string to sql.NullInt64: .*`)
+
+	strict, err := cast.New(cast.Strict, cast.NilError)
+	c.Assert(err, IsNil)
+	n, err := strict.ToNullInt64E(nil)
+	c.Assert(err, IsNil)
+	c.Assert(n.Valid, IsFalse)
+	_, err = strict.ToNullInt64E(1.5)
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+
+	nt, err := cast.ToNullTimeE(0, time.FixedZone("UTC+1", 3600))
+	c.Assert(err, IsNil)
+	c.Assert(nt.Time.Location().String(), Equals, "UTC+1")
+
+	var out struct {
+		ID   sql.NullInt64
+		Name sql.NullString
+		Note *sql.NullString
+	}
+	c.Assert(cast.Decode(map[string]any{"id": "12", "name": nil, "note": "x"}, &out), IsNil)
+	c.Assert(out.ID, Equals, sql.NullInt64{Int64: 12, Valid: true})
+	c.Assert(out.Name, Equals, sql.NullString{})
+	c.Assert(*out.Note, Equals, sql.NullString{String: "x", Valid: true})
+}
+
+func TestScanInto(t *testing.T) {
+	c := New(t)
+
+	var n sql.NullInt64
+	c.Assert(cast.ScanInto(&n, "42"), IsNil)
+	c.Assert(n, Equals, sql.NullInt64{Int64: 42, Valid: true})
+	c.Assert(cast.ScanInto(&n, nil), IsNil)
+	c.Assert(n, Equals, sql.NullInt64{})
+	c.Assert(cast.ScanInto(&n, "4.5"), IsNil)
+	c.Assert(n.Int64, Equals, int64(4))
+	err := cast.ScanInto(&n, "abc")
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+
+	strict, err := cast.New(cast.Strict)
+	c.Assert(err, IsNil)
+	err = strict.ScanInto(&n, "4.5")
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+
+	tests := []struct {
+		input  any
+		expect any
+	}{
+		{nil, nil},
+		{int8(8), int64(8)},
+		{uint16(16), int64(16)},
+		{float32(0.5), float64(0.5)},
+		{"text", "text"},
+		{[]byte("raw"), []byte("raw")},
+		{sql.NullString{String: "valid", Valid: true}, "valid"},
+		{big.NewRat(1, 4), "1/4"},
+		{uint64(1 << 63), "9223372036854775808"},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		var s scanned
+		c.Assert(cast.ScanInto(&s, test.input), IsNil, errmsg)
+		c.Assert(s.value, DeepEquals, test.expect, errmsg)
+	}
+
+	err = cast.ScanInto(&scanned{}, true)
+	c.Assert(err, ErrorMatches, `unable to cast true of type bool to \*cast_test.scanned: unexpected bool`)
+	err = cast.ScanInto((*sql.NullInt64)(nil), 1)
+	c.Assert(errors.Is(err, cast.ErrUnsupported), IsTrue)
+}
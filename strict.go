@@ -0,0 +1,172 @@
+package cast
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// Mode is an option of New, To and Decode selecting how casts that lose
+// information are treated.
+type Mode int
+
+const (
+	// Lenient casts truncate, round and drop parts of values as the ToXxxE
+	// functions do. It is the default.
+	Lenient Mode = iota
+	// Strict casts fail with ErrInexact unless the result has exactly the
+	// value of the input: 8.31 and "8.5" are not ints, complex(3, 4) is not
+	// a float64 and 2.5 is not a bool. A float written as text, or held in a
+	// decimal.Decimal, has the value of its shortest decimal form, so "0.1"
+	// is a float64 and float64(0.1) is "0.1".
+	Strict
+)
+
+// component is a real number, exact in r when it is finite and held in f
+// otherwise.
+type component struct {
+	r *big.Rat
+	f float64
+}
+
+func (c component) equal(d component) bool {
+	switch {
+	case c.r != nil && d.r != nil:
+		return c.r.Cmp(d.r) == 0
+	case c.r == nil && d.r == nil:
+		return c.f == d.f || math.IsNaN(c.f) && math.IsNaN(d.f)
+	default:
+		return false
+	}
+}
+
+// checkExact reports an ErrInexact error when the value of the result v of
+// casting a differs from the value of a. Values that are not numbers, such as
+// times or text that does not parse, are not compared.
+func (c *Caster) checkExact(a, v any, to string) error {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		a = b
+	}
+	if b, ok := indirectToBuiltin(v); ok {
+		v = b
+	}
+
+	are, aim, ok := c.exactValue(a, isDecimalText(v))
+	if !ok {
+		return nil
+	}
+	vre, vim, ok := c.exactValue(v, isDecimalText(a))
+	if !ok {
+		return nil
+	}
+	if !are.equal(vre) || !aim.equal(vim) {
+		return newCastError(a, to, ErrInexact, nil)
+	}
+	return nil
+}
+
+// isDecimalText reports whether a holds a number in decimal notation.
+func isDecimalText(a any) bool {
+	switch a.(type) {
+	case string, []byte, fmt.Stringer, error, decimal.Decimal:
+		return true
+	}
+	return false
+}
+
+// exactValue returns the real and imaginary parts of the number a. A binary
+// float has the value of its shortest decimal form when shortest is set, as
+// when it is compared to text.
+func (c *Caster) exactValue(a any, shortest bool) (re, im component, ok bool) {
+	zero := component{r: new(big.Rat)}
+	switch v := a.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, *big.Int, bool:
+		r, err := c.toBigRatE(v)
+		return component{r: r}, zero, err == nil
+	case *big.Rat:
+		return component{r: v}, zero, v != nil
+	case decimal.Decimal:
+		return component{r: v.Rat()}, zero, true
+	case *decimal.Decimal:
+		if v == nil {
+			return zero, zero, false
+		}
+		return component{r: v.Rat()}, zero, true
+	case float32:
+		return floatComponent(float64(v), 32, shortest), zero, true
+	case float64:
+		return floatComponent(v, 64, shortest), zero, true
+	case complex64:
+		return floatComponent(float64(real(v)), 32, shortest), floatComponent(float64(imag(v)), 32, shortest), true
+	case complex128:
+		return floatComponent(real(v), 64, shortest), floatComponent(imag(v), 64, shortest), true
+	case *big.Float:
+		if v == nil {
+			return zero, zero, false
+		}
+		if v.IsInf() {
+			f, _ := v.Float64()
+			return component{f: f}, zero, true
+		}
+		if shortest {
+			r, _ := new(big.Rat).SetString(v.Text('g', -1))
+			return component{r: r}, zero, true
+		}
+		r, _ := v.Rat(nil)
+		return component{r: r}, zero, true
+	case string:
+		return c.exactText(v)
+	case []byte:
+		return c.exactText(string(v))
+	case fmt.Stringer:
+		return c.exactText(v.String())
+	case error:
+		return c.exactText(v.Error())
+	default:
+		return zero, zero, false
+	}
+}
+
+func floatComponent(f float64, bitSize int, shortest bool) component {
+	switch {
+	case math.IsNaN(f) || math.IsInf(f, 0):
+		return component{f: f}
+	case shortest:
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, bitSize))
+		return component{r: r}
+	default:
+		return component{r: new(big.Rat).SetFloat64(f)}
+	}
+}
+
+func (c *Caster) exactText(s string) (re, im component, ok bool) {
+	if t, ok := c.dec.complexText(s); ok {
+		c, err := strconv.ParseComplex(t, 128)
+		if err != nil {
+			return component{}, component{}, false
+		}
+		return floatComponent(real(c), 64, true), floatComponent(imag(c), 64, true), true
+	}
+
+	n, err := c.dec.parseReal(s)
+	if err != nil {
+		return component{}, component{}, false
+	}
+	switch {
+	case n.rat != nil:
+		re = component{r: n.rat}
+	case n.nan:
+		re = component{f: math.NaN()}
+	case n.float.IsInf():
+		f, _ := n.float.Float64()
+		re = component{f: f}
+	default:
+		r, _ := n.float.Rat(nil)
+		re = component{r: r}
+	}
+	return re, component{r: new(big.Rat)}, true
+}
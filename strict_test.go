@@ -0,0 +1,87 @@
+package cast_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+	"github.com/shopspring/decimal"
+)
+
+func TestStrict(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		tove   func(any) (any, error)
+		input  any
+		expect any
+		iserr  bool
+	}{
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, 8.0, 8, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, "8.0", 8, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, "0x10", 16, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, "6/2", 3, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, true, 1, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, complex(3, 0), 3, false},
+		{func(v any) (any, error) { return cast.To[float64](v, cast.Strict) }, "0.1", 0.1, false},
+		{func(v any) (any, error) { return cast.To[float64](v, cast.Strict) }, float32(0.5), 0.5, false},
+		{func(v any) (any, error) { return cast.To[float64](v, cast.Strict) }, math.Inf(1), math.Inf(1), false},
+		{func(v any) (any, error) { return cast.To[float32](v, cast.Strict) }, "0.1", float32(0.1), false},
+		{func(v any) (any, error) { return cast.To[string](v, cast.Strict) }, 0.1, "0.1", false},
+		{func(v any) (any, error) { return cast.To[string](v, cast.Strict) }, "hello", "hello", false},
+		{func(v any) (any, error) { return cast.To[bool](v, cast.Strict) }, "t", true, false},
+		{func(v any) (any, error) { return cast.To[bool](v, cast.Strict) }, 0, false, false},
+		{func(v any) (any, error) { return cast.To[complex128](v, cast.Strict) }, "(0.1+2i)", complex(0.1, 2), false},
+		{func(v any) (any, error) { return cast.To[myInt](v, cast.Strict) }, "8", myInt(8), false},
+		{func(v any) (any, error) { return cast.To[[]int](v, cast.Strict) }, "1, 2", []int{1, 2}, false},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Lenient) }, 8.31, 8, false},
+		// lossy
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, 8.31, 0, true},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, "8.5", 0, true},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, complex(3, 4), 0, true},
+		{func(v any) (any, error) { return cast.To[int](v, cast.Strict) }, big.NewRat(1, 3), 0, true},
+		{func(v any) (any, error) { return cast.To[float32](v, cast.Strict) }, 0.1, float32(0), true},
+		{func(v any) (any, error) { return cast.To[float32](v, cast.Strict) }, "16777217", float32(0), true},
+		{func(v any) (any, error) { return cast.To[float64](v, cast.Strict) }, "0.10000000000000000001", 0.0, true},
+		{func(v any) (any, error) { return cast.To[float64](v, cast.Strict) }, "1/3", 0.0, true},
+		{func(v any) (any, error) { return cast.To[bool](v, cast.Strict) }, 2.5, false, true},
+		{func(v any) (any, error) { return cast.To[decimal.Decimal](v, cast.Strict) }, "1/3", decimal.Zero, true},
+		{func(v any) (any, error) { return cast.To[[]int](v, cast.Strict) }, []any{1, 2.5}, []int(nil), true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := test.tove(test.input)
+		if test.iserr {
+			c.Assert(errors.Is(err, cast.ErrInexact), IsTrue, errmsg)
+		} else {
+			c.Assert(err, IsNil, errmsg)
+		}
+		if d, ok := v.(decimal.Decimal); ok {
+			c.Assert(d.Equal(test.expect.(decimal.Decimal)), IsTrue, errmsg)
+			continue
+		}
+		c.Assert(v, DeepEquals, test.expect, errmsg)
+	}
+}
+
+func TestStrictDecode(t *testing.T) {
+	c := New(t)
+
+	var out struct {
+		Port int `cast:"port"`
+	}
+	err := cast.Decode(map[string]any{"port": "8.5"}, &out, cast.Strict)
+	c.Assert(errors.Is(err, cast.ErrInexact), IsTrue)
+	c.Assert(err, ErrorMatches, `.*field port: unable to cast "8.5" of type string to int: value not exactly representable`)
+
+	c.Assert(cast.Decode(map[string]any{"port": "8.5"}, &out), IsNil)
+	c.Assert(out.Port, Equals, 8)
+
+	_, err = cast.To[int](8, cast.Mode(3))
+	c.Assert(err, ErrorMatches, `unsupported option 3 of type cast.Mode`)
+}
@@ -3,6 +3,7 @@ package cast
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 
@@ -17,7 +18,19 @@ func ToString(a any) string {
 
 // ToStringE casts an interface to a string type.
 func ToStringE(a any) (string, error) {
+	return std.ToStringE(a)
+}
+
+// ToStringE casts an interface to a string type with the settings of c.
+func (c *Caster) ToStringE(a any) (string, error) {
+	return castBuiltin(c, a, (*Caster).toStringE)
+}
+
+func (c *Caster) toStringE(a any) (string, error) {
 	a = indirectToStringerOrError(a)
+	if s, ok := c.formatNumber(a); ok {
+		return s, nil
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -41,25 +54,26 @@ func ToStringE(a any) (string, error) {
 	case uint64:
 		return strconv.FormatUint(uint64(v), 10), nil
 	case float32:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		//		return strconv.FormatFloat(float64(s), 'f', -1, 32), nil
-		return decimal.NewFromFloat32(v).String(), nil
+		return c.formatFloat(float64(v), 32), nil
 	case float64:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		// 		return strconv.FormatFloat(s, 'f', -1, 64), nil
-		return decimal.NewFromFloat(v).String(), nil
+		return c.formatFloat(v, 64), nil
 	case *big.Int:
 		return v.String(), nil
 	case *big.Float:
 		return v.String(), nil
 	case *big.Rat:
 		return v.String(), nil
+	case decimal.Decimal:
+		return v.String(), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return "", newCastError(a, "string", ErrUnsupported, nil)
+		}
+		return v.String(), nil
 	case complex64:
-		return fmt.Sprintf("(%v+%vi)", real(v), imag(v)), nil
+		return c.formatComplex(complex128(v), 64), nil
 	case complex128:
-		return fmt.Sprintf("(%v+%vi)", real(v), imag(v)), nil
+		return c.formatComplex(v, 128), nil
 	case bool:
 		return strconv.FormatBool(v), nil
 	case string:
@@ -73,7 +87,11 @@ func ToStringE(a any) (string, error) {
 	case nil:
 		return "", nil
 	default:
-		return "", fmt.Errorf("unable to cast %#v of type %T to string", a, a)
+		if b, ok := indirectToBuiltin(a); ok {
+			v, err := c.toStringE(b)
+			return v, wrapError(a, "string", err)
+		}
+		return "", newCastError(a, "string", ErrUnsupported, nil)
 	}
 }
 
@@ -85,7 +103,19 @@ func ToBytes(a any) []byte {
 
 // ToBytesE casts an interface to a []byte type.
 func ToBytesE(a any) ([]byte, error) {
+	return std.ToBytesE(a)
+}
+
+// ToBytesE casts an interface to a []byte type with the settings of c.
+func (c *Caster) ToBytesE(a any) ([]byte, error) {
+	return castBuiltin(c, a, (*Caster).toBytesE)
+}
+
+func (c *Caster) toBytesE(a any) ([]byte, error) {
 	a = indirectToStringerOrError(a)
+	if s, ok := c.formatNumber(a); ok {
+		return []byte(s), nil
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -109,25 +139,26 @@ func ToBytesE(a any) ([]byte, error) {
 	case uint64:
 		return []byte(strconv.FormatUint(uint64(v), 10)), nil
 	case float32:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		//		return []byte(strconv.FormatFloat(float64(s), 'f', -1, 32)), nil
-		return []byte(decimal.NewFromFloat32(v).String()), nil
+		return []byte(c.formatFloat(float64(v), 32)), nil
 	case float64:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		// 		return []byte(strconv.FormatFloat(s, 'f', -1, 64)), nil
-		return []byte(decimal.NewFromFloat(v).String()), nil
+		return []byte(c.formatFloat(v, 64)), nil
 	case *big.Int:
 		return []byte(v.String()), nil
 	case *big.Float:
 		return []byte(v.String()), nil
 	case *big.Rat:
 		return []byte(v.String()), nil
+	case decimal.Decimal:
+		return []byte(v.String()), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return []byte{}, newCastError(a, "[]byte", ErrUnsupported, nil)
+		}
+		return []byte(v.String()), nil
 	case complex64:
-		return []byte(fmt.Sprintf("(%v+%vi)", real(v), imag(v))), nil
+		return []byte(c.formatComplex(complex128(v), 64)), nil
 	case complex128:
-		return []byte(fmt.Sprintf("(%v+%vi)", real(v), imag(v))), nil
+		return []byte(c.formatComplex(v, 128)), nil
 	case bool:
 		return []byte(strconv.FormatBool(v)), nil
 	case string:
@@ -141,7 +172,11 @@ func ToBytesE(a any) ([]byte, error) {
 	case nil:
 		return []byte{}, nil
 	default:
-		return []byte{}, fmt.Errorf("unable to cast %#v of type %T to []byte", a, a)
+		if b, ok := indirectToBuiltin(a); ok {
+			v, err := c.toBytesE(b)
+			return v, wrapError(a, "[]byte", err)
+		}
+		return []byte{}, newCastError(a, "[]byte", ErrUnsupported, nil)
 	}
 }
 
@@ -153,7 +188,19 @@ func ToStringer(a any) fmt.Stringer {
 
 // ToStringerE casts an interface to a fmt.Stringer type.
 func ToStringerE(a any) (fmt.Stringer, error) {
+	return std.ToStringerE(a)
+}
+
+// ToStringerE casts an interface to a fmt.Stringer type with the settings of c.
+func (c *Caster) ToStringerE(a any) (fmt.Stringer, error) {
+	return castTo[fmt.Stringer](c, a)
+}
+
+func (c *Caster) toStringerE(a any) (fmt.Stringer, error) {
 	a = indirectToStringerOrError(a)
+	if s, ok := c.formatNumber(a); ok {
+		return stringer{s}, nil
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -177,25 +224,26 @@ func ToStringerE(a any) (fmt.Stringer, error) {
 	case uint64:
 		return stringer{strconv.FormatUint(uint64(v), 10)}, nil
 	case float32:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		//		return stringer{strconv.FormatFloat(float64(s), 'f', -1, 32)}, nil
-		return stringer{decimal.NewFromFloat32(v).String()}, nil
+		return stringer{c.formatFloat(float64(v), 32)}, nil
 	case float64:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		// 		return stringer{strconv.FormatFloat(s, 'f', -1, 64)}, nil
-		return stringer{decimal.NewFromFloat(v).String()}, nil
+		return stringer{c.formatFloat(v, 64)}, nil
 	case *big.Int:
 		return stringer{v.String()}, nil
 	case *big.Float:
 		return stringer{v.String()}, nil
 	case *big.Rat:
 		return stringer{v.String()}, nil
+	case decimal.Decimal:
+		return stringer{v.String()}, nil
+	case *decimal.Decimal:
+		if v == nil {
+			return nil, newCastError(a, "fmt.Stringer", ErrUnsupported, nil)
+		}
+		return stringer{v.String()}, nil
 	case complex64:
-		return stringer{fmt.Sprintf("(%v+%vi)", real(v), imag(v))}, nil
+		return stringer{c.formatComplex(complex128(v), 64)}, nil
 	case complex128:
-		return stringer{fmt.Sprintf("(%v+%vi)", real(v), imag(v))}, nil
+		return stringer{c.formatComplex(v, 128)}, nil
 	case bool:
 		return stringer{strconv.FormatBool(v)}, nil
 	case string:
@@ -209,7 +257,11 @@ func ToStringerE(a any) (fmt.Stringer, error) {
 	case nil:
 		return nil, nil
 	default:
-		return nil, fmt.Errorf("unable to cast %#v of type %T to fmt.Stringer", a, a)
+		if b, ok := indirectToBuiltin(a); ok {
+			v, err := c.toStringerE(b)
+			return v, wrapError(a, "fmt.Stringer", err)
+		}
+		return nil, newCastError(a, "fmt.Stringer", ErrUnsupported, nil)
 	}
 }
 
@@ -221,7 +273,19 @@ func ToError(a any) error {
 
 // ToErrorE casts an interface to an error type.
 func ToErrorE(a any) (error, error) {
+	return std.ToErrorE(a)
+}
+
+// ToErrorE casts an interface to an error type with the settings of c.
+func (c *Caster) ToErrorE(a any) (error, error) {
+	return castTo[error](c, a)
+}
+
+func (c *Caster) toErrorE(a any) (error, error) {
 	a = indirectToStringerOrError(a)
+	if s, ok := c.formatNumber(a); ok {
+		return errors.New(s), nil
+	}
 
 	switch v := a.(type) {
 	case int:
@@ -245,25 +309,26 @@ func ToErrorE(a any) (error, error) {
 	case uint64:
 		return errors.New(strconv.FormatUint(uint64(v), 10)), nil
 	case float32:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		//		return errors.New(strconv.FormatFloat(float64(s), 'f', -1, 32)), nil
-		return errors.New(decimal.NewFromFloat32(v).String()), nil
+		return errors.New(c.formatFloat(float64(v), 32)), nil
 	case float64:
-		// Use decimal to fix precision issue, FormatFloat is unstable.
-		// Optional:
-		// 		return errors.New(strconv.FormatFloat(s, 'f', -1, 64)), nil
-		return errors.New(decimal.NewFromFloat(v).String()), nil
+		return errors.New(c.formatFloat(v, 64)), nil
 	case *big.Int:
 		return errors.New(v.String()), nil
 	case *big.Float:
 		return errors.New(v.String()), nil
 	case *big.Rat:
 		return errors.New(v.String()), nil
+	case decimal.Decimal:
+		return errors.New(v.String()), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return nil, newCastError(a, "error", ErrUnsupported, nil)
+		}
+		return errors.New(v.String()), nil
 	case complex64:
-		return errors.New(fmt.Sprintf("(%v+%vi)", real(v), imag(v))), nil
+		return errors.New(c.formatComplex(complex128(v), 64)), nil
 	case complex128:
-		return errors.New(fmt.Sprintf("(%v+%vi)", real(v), imag(v))), nil
+		return errors.New(c.formatComplex(v, 128)), nil
 	case bool:
 		return errors.New(strconv.FormatBool(v)), nil
 	case string:
@@ -277,6 +342,41 @@ func ToErrorE(a any) (error, error) {
 	case nil:
 		return nil, nil
 	default:
-		return nil, fmt.Errorf("unable to cast %#v of type %T to error", a, a)
+		if b, ok := indirectToBuiltin(a); ok {
+			v, err := c.toErrorE(b)
+			return v, wrapError(a, "error", err)
+		}
+		return nil, newCastError(a, "error", ErrUnsupported, nil)
+	}
+}
+
+// formatFloat formats f, a float of the given bit size, with the FloatFormat
+// of c. The default is the shortest decimal form of f without an exponent.
+func (c *Caster) formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsInf(f, 0) || math.IsNaN(f):
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	case c.floats.Verb == 0:
+		// Unlike FormatFloat with 'f' and -1, decimal writes -0 as "0".
+		if bitSize == 32 {
+			return decimal.NewFromFloat32(float32(f)).String()
+		}
+		return decimal.NewFromFloat(f).String()
+	default:
+		return strconv.FormatFloat(f, c.floats.Verb, c.floats.Precision, bitSize)
+	}
+}
+
+// formatComplex formats v, a complex number of the given bit size, with the
+// ComplexFormat of c.
+func (c *Caster) formatComplex(v complex128, bitSize int) string {
+	verb, precision := c.complexes.Verb, c.complexes.Precision
+	if verb == 0 {
+		verb, precision = 'g', -1
+	}
+	s := strconv.FormatComplex(v, verb, precision, bitSize)
+	if c.complexes.NoParens {
+		s = s[1 : len(s)-1]
 	}
+	return s
 }
@@ -3,6 +3,7 @@ package cast_test
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -302,3 +303,138 @@ func TestToErrorE(t *testing.T) {
 		func(v any) any { return cast.ToError(v) },
 	)
 }
+
+func TestFloatFormat(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		format cast.FloatFormat
+		input  any
+		expect string
+	}{
+		{cast.DecimalFloats, 1e21, "1000000000000000000000"},
+		{cast.DecimalFloats, 1e-7, "0.0000001"},
+		{cast.DecimalFloats, float32(0.1), "0.1"},
+		{cast.DecimalFloats, math.Copysign(0, -1), "0"},
+		{cast.DecimalFloats, math.NaN(), "NaN"},
+		{cast.DecimalFloats, math.Inf(1), "+Inf"},
+		{cast.DecimalFloats, float32(math.Inf(-1)), "-Inf"},
+		{cast.ShortestFloats, 1e21, "1e+21"},
+		{cast.ShortestFloats, 1e-7, "1e-07"},
+		{cast.ShortestFloats, 0.1, "0.1"},
+		{cast.ShortestFloats, float32(16777216.0), "1.6777216e+07"},
+		{cast.ShortestFloats, float32(0.1), "0.1"},
+		{cast.ShortestFloats, math.Inf(-1), "-Inf"},
+		{cast.FixedFloats(2), 3.14159, "3.14"},
+		{cast.FixedFloats(2), float32(2.5), "2.50"},
+		{cast.FixedFloats(0), 2.5, "2"},
+		{cast.FixedFloats(2), math.NaN(), "NaN"},
+		{cast.FloatFormat{Verb: 'e', Precision: 3}, 1234.5678, "1.235e+03"},
+		{cast.FloatFormat{Verb: 'g', Precision: 4}, 1234.5678, "1235"},
+		{cast.FloatFormat{Verb: 'x', Precision: -1}, 1.0, "0x1p+00"},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, format = %+v, input = %#v", i, test.format, test.input)
+
+		caster, err := cast.New(test.format)
+		c.Assert(err, IsNil, errmsg)
+		v, err := caster.ToStringE(test.input)
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+		b, err := caster.ToBytesE(test.input)
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(string(b), Equals, test.expect, errmsg)
+		s, err := caster.ToStringerE(test.input)
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(s.String(), Equals, test.expect, errmsg)
+		e, err := caster.ToErrorE(test.input)
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(e.Error(), Equals, test.expect, errmsg)
+	}
+
+	// The package functions no longer panic on NaN and the infinities.
+	c.Assert(cast.ToString(math.NaN()), Equals, "NaN")
+	c.Assert(cast.ToString(math.Inf(1)), Equals, "+Inf")
+	c.Assert(cast.ToStringSlice([]float32{1.5, float32(math.Inf(-1))}), DeepEquals, []string{"1.5", "-Inf"})
+}
+
+func TestComplexFormat(t *testing.T) {
+	c := New(t)
+
+	tests := []struct {
+		format cast.ComplexFormat
+		input  any
+		expect string
+	}{
+		{cast.ComplexFormat{}, complex(1, -2), "(1-2i)"},
+		{cast.ComplexFormat{}, complex64(complex(0.1, 2.5)), "(0.1+2.5i)"},
+		{cast.ComplexFormat{}, complex(1e21, 1e-7), "(1e+21+1e-07i)"},
+		{cast.ComplexFormat{}, complex(math.NaN(), math.Inf(1)), "(NaN+Infi)"},
+		{cast.ComplexFormat{}, complex(1, math.NaN()), "(1+NaNi)"},
+		{cast.ComplexFormat{NoParens: true}, complex(1, -2), "1-2i"},
+		{cast.ComplexFormat{Verb: 'f', Precision: 2}, complex(1.005, -1.0/3), "(1.00-0.33i)"},
+		{cast.ComplexFormat{Verb: 'e', Precision: 1, NoParens: true}, complex64(complex(1234, 0)), "1.2e+03+0.0e+00i"},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, format = %+v, input = %#v", i, test.format, test.input)
+
+		caster, err := cast.New(test.format)
+		c.Assert(err, IsNil, errmsg)
+		v, err := caster.ToStringE(test.input)
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+
+	_, err := cast.New(cast.ComplexFormat{Verb: 'v'})
+	c.Assert(err, ErrorMatches, `unsupported option .*`)
+}
+
+func TestComplexRoundTrip(t *testing.T) {
+	c := New(t)
+
+	nan, inf, negZero := math.NaN(), math.Inf(1), math.Copysign(0, -1)
+	parts := []float64{
+		0, negZero, 1, -1, 0.1, -2.5, 1e21, 1e-7, math.MaxFloat64, -math.SmallestNonzeroFloat64,
+		math.MaxFloat32, math.SmallestNonzeroFloat32, nan, inf, -inf,
+	}
+	noParens, err := cast.New(cast.ComplexFormat{NoParens: true})
+	c.Assert(err, IsNil)
+	e, err := cast.New(cast.ComplexFormat{Verb: 'e', Precision: -1})
+	c.Assert(err, IsNil)
+	casters := []*cast.Caster{nil, noParens, e}
+
+	sameFloat := func(x, y float64) bool {
+		if math.IsNaN(x) {
+			return math.IsNaN(y)
+		}
+		return x == y && math.Signbit(x) == math.Signbit(y)
+	}
+	for _, re := range parts {
+		for _, im := range parts {
+			for _, caster := range casters {
+				toString, toComplex128, toComplex64 := cast.ToStringE, cast.ToComplex128E, cast.ToComplex64E
+				if caster != nil {
+					toString, toComplex128, toComplex64 = caster.ToStringE, caster.ToComplex128E, caster.ToComplex64E
+				}
+
+				v := complex(re, im)
+				s, err := toString(v)
+				c.Assert(err, IsNil)
+				w, err := toComplex128(s)
+				errmsg := Commentf("v = %v, s = %q, w = %v", v, s, w)
+				c.Assert(err, IsNil, errmsg)
+				c.Assert(sameFloat(real(w), real(v)) && sameFloat(imag(w), imag(v)), IsTrue, errmsg)
+
+				v32 := complex64(v)
+				s, err = toString(v32)
+				c.Assert(err, IsNil)
+				w32, err := toComplex64(s)
+				errmsg = Commentf("v = %v, s = %q, w = %v", v32, s, w32)
+				c.Assert(err, IsNil, errmsg)
+				c.Assert(sameFloat(float64(real(w32)), float64(real(v32))) && sameFloat(float64(imag(w32)), float64(imag(v32))), IsTrue, errmsg)
+			}
+		}
+	}
+}
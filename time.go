@@ -1,329 +1,401 @@
 package cast
 
-// TODO support time & duration
-
-// // ToTime casts an interface to a time.Time type.
-// func ToTime(a any, args ...any) time.Time {
-// 	t, _ := ToTimeE(a)
-// 	return t
-// }
-
-// // ToTimeE casts an interface to a time.Time type.
-// func ToTimeE(a any, args ...any) (time.Time, error) {
-// 	a = indirectToStringerOrError(a)
-
-// 	switch v := a.(type) {
-// 	case int:
-// 		return time.Unix(int64(v), 0), nil
-// 	case int8:
-// 		return time.Unix(int64(v), 0), nil
-// 	case int16:
-// 		return time.Unix(int64(v), 0), nil
-// 	case int32:
-// 		return time.Unix(int64(v), 0), nil
-// 	case int64:
-// 		return time.Unix(v, 0), nil
-// 	case uint:
-// 		return time.Unix(int64(v), 0), nil
-// 	case uint8:
-// 		return time.Unix(int64(v), 0), nil
-// 	case uint16:
-// 		return time.Unix(int64(v), 0), nil
-// 	case uint32:
-// 		return time.Unix(int64(v), 0), nil
-// 	case uint64:
-// 		return time.Unix(int64(v), 0), nil
-// 	case float32:
-// 		return time.Unix(int64(v), 0), nil
-// 	case float64:
-// 		return time.Unix(int64(v), 0), nil
-// 	case *big.Int:
-// 		return time.Unix(v.Int64(), 0), nil
-// 	case *big.Float:
-// 		n, _ := v.Int64()
-// 		return time.Unix(n, 0), nil
-// 	case *big.Rat:
-// 		n, _ := v.Float64()
-// 		return time.Unix(int64(n), 0), nil
-// 	case complex64:
-// 		return time.Unix(int64(real(v)), 0), nil
-// 	case complex128:
-// 		return time.Unix(int64(real(v)), 0), nil
-// 	case bool:
-// 		if v {
-// 			return time.Unix(1, 0), nil
-// 		}
-// 		return time.Unix(0, 0), nil
-// 	case string:
-// 		return parseTime(v, args...)
-// 	case []byte:
-// 		return parseTime(string(v), args...)
-// 	case fmt.Stringer:
-// 		return parseTime(v.String(), args...)
-// 	case nil:
-// 		return time.Time{}, nil
-// 	default:
-// 		return time.Time{}, fmt.Errorf("unable to cast %#v of type %T to Time", a, a)
-// 	}
-// }
-
-// // ToDuration casts an interface to a time.Duration type.
-// func ToDuration(i any, args ...any) time.Duration {
-// 	d, _ := ToDurationE(i)
-// 	return d
-// }
-
-// // ToDurationE casts an interface to a time.Duration type.
-// func ToDurationE(a any, args ...any) (d time.Duration, err error) {
-// 	a = indirectToStringerOrError(a)
-
-// 	switch v := a.(type) {
-// 	case int:
-// 		return time.Duration(v), nil
-// 	case int8:
-// 		return time.Duration(v), nil
-// 	case int16:
-// 		return time.Duration(v), nil
-// 	case int32:
-// 		return time.Duration(v), nil
-// 	case int64:
-// 		return time.Duration(v), nil
-// 	case uint:
-// 		return time.Duration(v), nil
-// 	case uint8:
-// 		return time.Duration(v), nil
-// 	case uint16:
-// 		return time.Duration(v), nil
-// 	case uint32:
-// 		return time.Duration(v), nil
-// 	case uint64:
-// 		return time.Duration(v), nil
-// 	case float32:
-// 		return time.Duration(v), nil
-// 	case float64:
-// 		return time.Duration(v), nil
-// 	case *big.Int:
-// 		return time.Duration(v.Int64()), nil
-// 	case *big.Float:
-// 		n, _ := v.Int64()
-// 		return time.Duration(n), nil
-// 	case *big.Rat:
-// 		n, _ := v.Float64()
-// 		return time.Duration(n), nil
-// 	case complex64:
-// 		return time.Duration(real(v)), nil
-// 	case complex128:
-// 		return time.Duration(real(v)), nil
-// 	case bool:
-// 		if v {
-// 			return time.Duration(1), nil
-// 		}
-// 		return time.Duration(0), nil
-// 	case string:
-// 		return parseDuration(v, args...)
-// 	case []byte:
-// 		return parseDuration(string(v), args...)
-// 	case fmt.Stringer:
-// 		return parseDuration(v.String(), args...)
-// 	case error:
-// 		return parseDuration(v.Error(), args...)
-// 	default:
-// 		return time.Duration(0), fmt.Errorf("unable to cast %#v of type %T to Duration", a, a)
-// 	}
-// }
-
-// type TimeFormatType int
-
-// const (
-// 	TimeFormatNoTimezone TimeFormatType = iota
-// 	TimeFormatNamedTimezone
-// 	TimeFormatNumericTimezone
-// 	TimeFormatNumericAndNamedTimezone
-// 	TimeFormatTimeOnly
-// )
-
-// func _() {
-// 	// An "invalid array index" compiler error signifies that the constant values have changed.
-// 	// Re-run the stringer command to generate them again.
-// 	var x [1]struct{}
-// 	_ = x[TimeFormatNoTimezone-0]
-// 	_ = x[TimeFormatNamedTimezone-1]
-// 	_ = x[TimeFormatNumericTimezone-2]
-// 	_ = x[TimeFormatNumericAndNamedTimezone-3]
-// 	_ = x[TimeFormatTimeOnly-4]
-// }
-
-// const _timeFormatType_name = "timeFormatNoTimezonetimeFormatNamedTimezonetimeFormatNumericTimezonetimeFormatNumericAndNamedTimezonetimeFormatTimeOnly"
-
-// var _timeFormatType_index = [...]uint8{0, 20, 43, 68, 101, 119}
-
-// func (i TimeFormatType) String() string {
-// 	if i < 0 || i >= TimeFormatType(len(_timeFormatType_index)-1) {
-// 		return "timeFormatType(" + strconv.FormatInt(int64(i), 10) + ")"
-// 	}
-// 	return _timeFormatType_name[_timeFormatType_index[i]:_timeFormatType_index[i+1]]
-// }
-
-// type TimeFormat struct {
-// 	Format string
-// 	Type   TimeFormatType
-// }
-
-// func (f TimeFormat) hasTimezone() bool {
-// 	// We don't include the formats with only named timezones, see
-// 	// https://github.com/golang/go/issues/19694#issuecomment-289103522
-// 	return f.Type >= TimeFormatNumericTimezone && f.Type <= TimeFormatNumericAndNamedTimezone
-// }
-
-// var (
-// 	defaultTimeFormats = []TimeFormat{
-// 		{time.RFC3339, TimeFormatNumericTimezone},
-// 		{"2006-01-02T15:04:05", TimeFormatNoTimezone}, // iso8601 without timezone
-// 		{time.RFC1123Z, TimeFormatNumericTimezone},
-// 		{time.RFC1123, TimeFormatNamedTimezone},
-// 		{time.RFC822Z, TimeFormatNumericTimezone},
-// 		{time.RFC822, TimeFormatNamedTimezone},
-// 		{time.RFC850, TimeFormatNamedTimezone},
-// 		{"2006-01-02 15:04:05.999999999 -0700 MST", TimeFormatNumericAndNamedTimezone}, // Time.String()
-// 		{"2006-01-02T15:04:05-0700", TimeFormatNumericTimezone},                        // RFC3339 without timezone hh:mm colon
-// 		{"2006-01-02 15:04:05Z0700", TimeFormatNumericTimezone},                        // RFC3339 without T or timezone hh:mm colon
-// 		{"2006-01-02 15:04:05", TimeFormatNoTimezone},
-// 		{time.ANSIC, TimeFormatNoTimezone},
-// 		{time.UnixDate, TimeFormatNamedTimezone},
-// 		{time.RubyDate, TimeFormatNumericTimezone},
-// 		{"2006-01-02 15:04:05Z07:00", TimeFormatNumericTimezone},
-// 		{"2006-01-02", TimeFormatNoTimezone},
-// 		{"02 Jan 2006", TimeFormatNoTimezone},
-// 		{"2006-01-02 15:04:05 -07:00", TimeFormatNumericTimezone},
-// 		{"2006-01-02 15:04:05 -0700", TimeFormatNumericTimezone},
-// 		{time.Kitchen, TimeFormatTimeOnly},
-// 		{time.Stamp, TimeFormatTimeOnly},
-// 		{time.StampMilli, TimeFormatTimeOnly},
-// 		{time.StampMicro, TimeFormatTimeOnly},
-// 		{time.StampNano, TimeFormatTimeOnly},
-// 	}
-
-// 	location = time.UTC
-// )
-
-// func parseTime(s string, args ...any) (d time.Time, e error) {
-// 	var (
-// 		location    *time.Location
-// 		timeFormats []TimeFormat
-// 	)
-// 	for _, arg := range args {
-// 		switch v := arg.(type) {
-// 		case *time.Location:
-// 			location = v
-// 		case TimeFormat:
-// 			timeFormats = append(timeFormats, v)
-// 		}
-// 	}
-
-// 	if location == nil {
-// 		location = time.Local
-// 	}
-// 	if len(timeFormats) == 0 {
-// 		timeFormats = defaultTimeFormats
-// 	}
-
-// 	for _, timeFormat := range timeFormats {
-// 		if d, e = time.Parse(timeFormat.Format, s); e == nil {
-
-// 			// Some time formats have a zone name, but no offset, so it gets
-// 			// put in that zone name (not the default one passed in to us), but
-// 			// without that zone's offset. So set the location manually.
-// 			if timeFormat.Type <= TimeFormatNamedTimezone {
-// 				if location == nil {
-// 					location = time.Local
-// 				}
-// 				year, month, day := d.Date()
-// 				hour, min, sec := d.Clock()
-// 				d = time.Date(year, month, day, hour, min, sec, d.Nanosecond(), location)
-// 			}
-
-// 			return
-// 		}
-// 	}
-// 	return d, fmt.Errorf("unable to parse date: %s", s)
-// }
-
-// var (
-// 	durationRegExp       *regexp.Regexp
-// 	durationRegExpGroups = []string{
-// 		`<years>[\+|\-]?\d+y`,
-// 		`<months>[\+|\-]?\d+M`,
-// 		`<days>[\+|\-]?\d+d`,
-// 		`<hours>[\+|\-]?\d+h`,
-// 		`<minutes>[\+|\-]?\d+m`,
-// 		`<seconds>[\+|\-]?\d+s`,
-// 		`<milliseconds>[\+|\-]?\d+ms`,
-// 		`<microseconds>[\+|\-]?\d+us`,
-// 		`<nanoseconds>[\+|\-]?\d+ns`,
-// 	}
-// )
-
-// func init() {
-// 	var buf = new(bytes.Buffer)
-// 	for _, group := range durationRegExpGroups {
-// 		buf.WriteString(`(?P`)
-// 		buf.WriteString(group)
-// 		buf.WriteString(`)?`)
-// 	}
-// 	durationRegExp = regexp.MustCompile(buf.String())
-// }
-
-// func parseDuration(s string, args ...any) (time.Duration, error) {
-// 	var (
-// 		t = time.Now()
-// 	)
-
-// 	for _, arg := range args {
-// 		switch v := arg.(type) {
-// 		case time.Time:
-// 			t = v
-// 		}
-// 	}
-
-// 	matches := durationRegExp.FindStringSubmatch(s)
-// 	if len(matches) == 0 {
-// 		return 0, nil
-// 	}
-
-// 	nums := []int{}
-// 	for index := 1; index < len(matches); index++ {
-// 		s := matches[index]
-// 		if len(s) == 0 {
-// 			nums = append(nums, 0)
-// 			continue
-// 		}
-// 		for s[len(s)-1] < '0' || s[len(s)-1] > '9' {
-// 			s = s[:len(s)-1]
-// 		}
-// 		n, err := strconv.ParseInt(s, 10, 64)
-// 		if err != nil {
-// 			return 0, err
-// 		}
-// 		nums = append(nums, int(n))
-// 	}
-
-// 	if len(nums) == 0 {
-// 		return 0, fmt.Errorf("parse duration `%s` failed", s)
-// 	}
-
-// 	duration := t.AddDate(nums[0], nums[1], nums[2]).Add(
-// 		time.Duration(nums[3]) * time.Hour,
-// 	).Add(
-// 		time.Duration(nums[4]) * time.Minute,
-// 	).Add(
-// 		time.Duration(nums[5]) * time.Second,
-// 	).Add(
-// 		time.Duration(nums[6]) * time.Millisecond,
-// 	).Add(
-// 		time.Duration(nums[7]) * time.Microsecond,
-// 	).Add(
-// 		time.Duration(nums[8]) * time.Nanosecond,
-// 	).Sub(t)
-
-// 	return duration, nil
-// }
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ToTime casts an interface to a time.Time type.
+func ToTime(a any, args ...any) time.Time {
+	t, _ := ToTimeE(a, args...)
+	return t
+}
+
+// ToTimeE casts an interface to a time.Time type.
+//
+// Numbers are interpreted as seconds since the Unix epoch, and strings are
+// parsed with the first matching layout of defaultTimeFormats. A string of
+// decimal digits matching no layout, such as "1700000000", is a number of
+// seconds too. The optional args customise the cast:
+//
+//   - a *time.Location is used for Unix timestamps and for layouts that
+//     carry no numeric timezone offset; it defaults to time.Local.
+//   - one or more TimeFormat values replace the default layouts.
+func ToTimeE(a any, args ...any) (time.Time, error) {
+	return std.ToTimeE(a, args...)
+}
+
+// ToTimeE casts an interface like the ToTimeE function, with the settings of
+// c. The args replace the location and layouts of c.
+func (c *Caster) ToTimeE(a any, args ...any) (time.Time, error) {
+	c, err := c.withTimeArgs(args)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return castBuiltin(c, a, (*Caster).toTimeE)
+}
+
+func (c *Caster) toTimeE(a any) (time.Time, error) {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		t, err := c.toTimeE(b)
+		return t, wrapError(a, "time.Time", err)
+	}
+
+	location := c.location
+	switch v := a.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, newCastError(a, "time.Time", ErrUnsupported, nil)
+		}
+		return *v, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, *big.Int:
+		n, err := c.toInt64E(v)
+		if err != nil {
+			return time.Time{}, wrapError(a, "time.Time", err)
+		}
+		return time.Unix(n, 0).In(location), nil
+	case float32, float64, *big.Float, *big.Rat, decimal.Decimal, *decimal.Decimal, complex64, complex128, json.Number:
+		r, err := c.toBigRatE(v)
+		if err != nil {
+			return time.Time{}, wrapError(a, "time.Time", err)
+		}
+		return unixRat(a, r, location)
+	case bool:
+		if v {
+			return time.Unix(1, 0).In(location), nil
+		}
+		return time.Unix(0, 0).In(location), nil
+	case string:
+		return c.parseTime(v, a)
+	case []byte:
+		return c.parseTime(string(v), a)
+	case fmt.Stringer:
+		return c.parseTime(v.String(), a)
+	case error:
+		return c.parseTime(v.Error(), a)
+	case nil:
+		return time.Time{}, nil
+	default:
+		return time.Time{}, newCastError(a, "time.Time", ErrUnsupported, nil)
+	}
+}
+
+// unixRat returns the time r seconds after the Unix epoch, truncated to the
+// nanosecond.
+func unixRat(a any, r *big.Rat, location *time.Location) (time.Time, error) {
+	ns := new(big.Int).Mul(r.Num(), big.NewInt(int64(time.Second)))
+	ns.Quo(ns, r.Denom())
+	sec, nsec := new(big.Int).QuoRem(ns, big.NewInt(int64(time.Second)), new(big.Int))
+	if !sec.IsInt64() {
+		return time.Time{}, newCastError(a, "time.Time", ErrOverflow, nil)
+	}
+	return time.Unix(sec.Int64(), nsec.Int64()).In(location), nil
+}
+
+// TimeFormatType describes the timezone information carried by a time layout.
+type TimeFormatType int
+
+const (
+	TimeFormatNoTimezone TimeFormatType = iota
+	TimeFormatNamedTimezone
+	TimeFormatNumericTimezone
+	TimeFormatNumericAndNamedTimezone
+	TimeFormatTimeOnly
+)
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[TimeFormatNoTimezone-0]
+	_ = x[TimeFormatNamedTimezone-1]
+	_ = x[TimeFormatNumericTimezone-2]
+	_ = x[TimeFormatNumericAndNamedTimezone-3]
+	_ = x[TimeFormatTimeOnly-4]
+}
+
+const _TimeFormatType_name = "TimeFormatNoTimezoneTimeFormatNamedTimezoneTimeFormatNumericTimezoneTimeFormatNumericAndNamedTimezoneTimeFormatTimeOnly"
+
+var _TimeFormatType_index = [...]uint8{0, 20, 43, 68, 101, 119}
+
+func (i TimeFormatType) String() string {
+	if i < 0 || i >= TimeFormatType(len(_TimeFormatType_index)-1) {
+		return "TimeFormatType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _TimeFormatType_name[_TimeFormatType_index[i]:_TimeFormatType_index[i+1]]
+}
+
+// TimeFormat is a layout accepted by time.Parse together with the kind of
+// timezone information it carries.
+type TimeFormat struct {
+	Format string
+	Type   TimeFormatType
+}
+
+func (f TimeFormat) hasTimezone() bool {
+	// We don't include the formats with only named timezones, see
+	// https://github.com/golang/go/issues/19694#issuecomment-289103522
+	return f.Type >= TimeFormatNumericTimezone && f.Type <= TimeFormatNumericAndNamedTimezone
+}
+
+var (
+	defaultTimeFormats = []TimeFormat{
+		{time.RFC3339, TimeFormatNumericTimezone},
+		{"2006-01-02T15:04:05", TimeFormatNoTimezone}, // iso8601 without timezone
+		{time.RFC1123Z, TimeFormatNumericTimezone},
+		{time.RFC1123, TimeFormatNamedTimezone},
+		{time.RFC822Z, TimeFormatNumericTimezone},
+		{time.RFC822, TimeFormatNamedTimezone},
+		{time.RFC850, TimeFormatNamedTimezone},
+		{"2006-01-02 15:04:05.999999999 -0700 MST", TimeFormatNumericAndNamedTimezone}, // Time.String()
+		{"2006-01-02T15:04:05-0700", TimeFormatNumericTimezone},                        // RFC3339 without timezone hh:mm colon
+		{"2006-01-02 15:04:05Z0700", TimeFormatNumericTimezone},                        // RFC3339 without T or timezone hh:mm colon
+		{"2006-01-02 15:04:05", TimeFormatNoTimezone},
+		{time.ANSIC, TimeFormatNoTimezone},
+		{time.UnixDate, TimeFormatNamedTimezone},
+		{time.RubyDate, TimeFormatNumericTimezone},
+		{"2006-01-02 15:04:05Z07:00", TimeFormatNumericTimezone},
+		{"2006-01-02", TimeFormatNoTimezone},
+		{"02 Jan 2006", TimeFormatNoTimezone},
+		{"2006-01-02 15:04:05 -07:00", TimeFormatNumericTimezone},
+		{"2006-01-02 15:04:05 -0700", TimeFormatNumericTimezone},
+		{time.Kitchen, TimeFormatTimeOnly},
+		{time.Stamp, TimeFormatTimeOnly},
+		{time.StampMilli, TimeFormatTimeOnly},
+		{time.StampMicro, TimeFormatTimeOnly},
+		{time.StampNano, TimeFormatTimeOnly},
+	}
+
+	defaultLocation = time.Local
+)
+
+// withTimeArgs returns c with the options accepted by ToTimeE applied.
+func (c *Caster) withTimeArgs(args []any) (*Caster, error) {
+	for _, arg := range args {
+		switch arg.(type) {
+		case *time.Location, TimeFormat:
+		default:
+			return nil, unsupportedOption(arg)
+		}
+	}
+	return c.with(args)
+}
+
+func (c *Caster) parseTime(s string, a any) (time.Time, error) {
+	for _, timeFormat := range c.timeFormats {
+		d, err := time.Parse(timeFormat.Format, s)
+		if err != nil {
+			continue
+		}
+
+		// Some time formats have a zone name, but no offset, so it gets
+		// put in that zone name (not the default one passed in to us), but
+		// without that zone's offset. So set the location manually, as for
+		// the formats without any timezone.
+		if !timeFormat.hasTimezone() {
+			year, month, day := d.Date()
+			hour, min, sec := d.Clock()
+			d = time.Date(year, month, day, hour, min, sec, d.Nanosecond(), c.location)
+		}
+		return d, nil
+	}
+
+	if s != "" && isDigits(s) {
+		n, _ := new(big.Int).SetString(s, 10)
+		return unixRat(a, new(big.Rat).SetInt(n), c.location)
+	}
+	return time.Time{}, newCastError(a, "time.Time", ErrSyntax, nil)
+}
+
+// ToDuration casts an interface to a time.Duration type.
+func ToDuration(a any, args ...any) time.Duration {
+	d, _ := ToDurationE(a, args...)
+	return d
+}
+
+// ToDurationE casts an interface to a time.Duration type.
+//
+// Numbers are interpreted as nanoseconds. Strings accept the syntax of
+// time.ParseDuration, such as "300ms", "-1.5h" or "2h45m", extended with the
+// calendar units "y" (years), "M" (months) and "d" (days). Each component may
+// carry its own sign, which applies to it and to the components that follow,
+// so "1y-2d" is two days short of a year. A string made of a single number
+// without a unit is a count of nanoseconds.
+//
+// Calendar units have no fixed length and are resolved relative to a reference
+// time, which is the Unix epoch in UTC unless a time.Time is passed in args.
+// Without a reference a month is thus 31 days and a year 365 days, whatever
+// the current date.
+func ToDurationE(a any, args ...any) (time.Duration, error) {
+	return std.ToDurationE(a, args...)
+}
+
+// ToDurationE casts an interface like the ToDurationE function, with the
+// settings of c. A time.Time in args replaces the reference time of c.
+func (c *Caster) ToDurationE(a any, args ...any) (time.Duration, error) {
+	c, err := c.withDurationArgs(args)
+	if err != nil {
+		return 0, err
+	}
+	return castBuiltin(c, a, (*Caster).toDurationE)
+}
+
+func (c *Caster) toDurationE(a any) (time.Duration, error) {
+	a = indirectToStringerOrError(a)
+	if b, ok := indirectToBuiltin(a); ok {
+		d, err := c.toDurationE(b)
+		return d, wrapError(a, "time.Duration", err)
+	}
+
+	switch v := a.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, *big.Int, *big.Float, *big.Rat, decimal.Decimal, *decimal.Decimal,
+		complex64, complex128, bool, json.Number:
+		n, err := c.toInt64E(v)
+		if err != nil {
+			return 0, wrapError(a, "time.Duration", err)
+		}
+		return time.Duration(n), nil
+	case string:
+		return parseDuration(v, a, c.referenceTime())
+	case []byte:
+		return parseDuration(string(v), a, c.referenceTime())
+	case fmt.Stringer:
+		return parseDuration(v.String(), a, c.referenceTime())
+	case error:
+		return parseDuration(v.Error(), a, c.referenceTime())
+	case nil:
+		return 0, nil
+	default:
+		return 0, newCastError(a, "time.Duration", ErrUnsupported, nil)
+	}
+}
+
+// withDurationArgs returns c with the options accepted by ToDurationE applied.
+func (c *Caster) withDurationArgs(args []any) (*Caster, error) {
+	for _, arg := range args {
+		if _, ok := arg.(time.Time); !ok {
+			return nil, unsupportedOption(arg)
+		}
+	}
+	return c.with(args)
+}
+
+// referenceTime returns the time relative to which calendar units are
+// resolved: the reference time of c, or the Unix epoch when it has none, so
+// that calendar units do not depend on the current date.
+func (c *Caster) referenceTime() time.Time {
+	if c.reference.IsZero() {
+		return defaultReference
+	}
+	return c.reference
+}
+
+// defaultReference is the reference time of calendar units when none is set.
+var defaultReference = time.Unix(0, 0).UTC()
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond, // U+00B5 = micro symbol
+	"μs": time.Microsecond, // U+03BC = Greek letter mu
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+func parseDuration(s string, a any, reference time.Time) (time.Duration, error) {
+	if s == "" {
+		return 0, newCastError(a, "time.Duration", ErrSyntax, nil)
+	}
+
+	var (
+		negative             bool
+		years, months, days  int
+		fixed                = new(big.Rat)
+		components, unitless int
+	)
+	for rest := s; rest != ""; {
+		if rest[0] == '-' || rest[0] == '+' {
+			negative = rest[0] == '-'
+			rest = rest[1:]
+		}
+
+		i := 0
+		for i < len(rest) && (rest[i] == '.' || '0' <= rest[i] && rest[i] <= '9') {
+			i++
+		}
+		j := i
+		for j < len(rest) && rest[j] != '.' && rest[j] != '-' && rest[j] != '+' && (rest[j] < '0' || rest[j] > '9') {
+			j++
+		}
+		number, unit := rest[:i], rest[i:j]
+		rest = rest[j:]
+
+		n, ok := new(big.Rat).SetString(number)
+		if number == "" || !ok {
+			return 0, newCastError(a, "time.Duration", ErrSyntax, nil)
+		}
+		if negative {
+			n.Neg(n)
+		}
+		components++
+
+		switch unit {
+		case "y", "M", "d":
+			if !n.IsInt() || !n.Num().IsInt64() || n.Num().Int64() != int64(int(n.Num().Int64())) {
+				return 0, newCastError(a, "time.Duration", ErrSyntax, nil)
+			}
+			switch unit {
+			case "y":
+				years += int(n.Num().Int64())
+			case "M":
+				months += int(n.Num().Int64())
+			case "d":
+				days += int(n.Num().Int64())
+			}
+		case "":
+			unitless++
+			fixed.Add(fixed, n)
+		default:
+			d, ok := durationUnits[unit]
+			if !ok {
+				return 0, newCastError(a, "time.Duration", ErrSyntax, nil)
+			}
+			fixed.Add(fixed, n.Mul(n, new(big.Rat).SetInt64(int64(d))))
+		}
+	}
+	// Only a lone number may omit its unit.
+	if unitless > 0 && components > 1 {
+		return 0, newCastError(a, "time.Duration", ErrSyntax, nil)
+	}
+
+	ns := new(big.Int).Quo(fixed.Num(), fixed.Denom())
+	if !ns.IsInt64() {
+		return 0, newCastError(a, "time.Duration", ErrOverflow, nil)
+	}
+	d := time.Duration(ns.Int64())
+
+	if years != 0 || months != 0 || days != 0 {
+		t := reference.AddDate(years, months, days)
+		calendar := t.Sub(reference)
+		if !reference.Add(calendar).Equal(t) {
+			return 0, newCastError(a, "time.Duration", ErrOverflow, nil)
+		}
+		if (d > 0 && calendar > math.MaxInt64-d) || (d < 0 && calendar < math.MinInt64-d) {
+			return 0, newCastError(a, "time.Duration", ErrOverflow, nil)
+		}
+		d += calendar
+	}
+	return d, nil
+}
@@ -1,62 +1,243 @@
 package cast_test
 
-// func TestToDurationE(t *testing.T) {
-// 	t.Error("Not implemented")
-// }
-
-// func TestToDurationE(t *testing.T) {
-// 	c := New(t)
-
-// 	var td time.Duration = 5
-// 	var jn json.Number
-// 	_ = json.Unmarshal([]byte("5"), &jn)
-
-// 	tests := []struct {
-// 		input  any
-// 		expect time.Duration
-// 		iserr  bool
-// 	}{
-// 		{time.Duration(5), td, false},
-// 		{int(5), td, false},
-// 		{int64(5), td, false},
-// 		{int32(5), td, false},
-// 		{int16(5), td, false},
-// 		{int8(5), td, false},
-// 		{uint(5), td, false},
-// 		{uint64(5), td, false},
-// 		{uint32(5), td, false},
-// 		{uint16(5), td, false},
-// 		{uint8(5), td, false},
-// 		{float64(5), td, false},
-// 		{float32(5), td, false},
-// 		{jn, td, false},
-// 		{string("5"), td, false},
-// 		{string("5ns"), td, false},
-// 		{string("5us"), time.Microsecond * td, false},
-// 		{string("5µs"), time.Microsecond * td, false},
-// 		{string("5ms"), time.Millisecond * td, false},
-// 		{string("5s"), time.Second * td, false},
-// 		{string("5m"), time.Minute * td, false},
-// 		{string("5h"), time.Hour * td, false},
-// 		// errors
-// 		{"test", 0, true},
-// 		{testing.T{}, 0, true},
-// 	}
-
-// 	for i, test := range tests {
-// 		errmsg := Commentf("i = %d", i) // assert helper message
-
-// 		v, err := ToDurationE(test.input)
-// 		if test.iserr {
-// 			c.Assert(err, IsNotNil)
-// 			continue
-// 		}
-
-// 		c.Assert(err, IsNil)
-// 		c.Assert(v, Equals, test.expect, errmsg)
-
-// 		// Non-E test
-// 		v = ToDuration(test.input)
-// 		c.Assert(v, Equals, test.expect, errmsg)
-// 	}
-// }
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/frankban/quicktest"
+	"github.com/golibraries/cast"
+)
+
+func TestToTimeE(t *testing.T) {
+	c := New(t)
+
+	utc := time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC)
+	local := time.Date(2009, 11, 10, 23, 0, 0, 0, time.Local)
+	unix := utc.Unix()
+	tests := []struct {
+		input  any
+		expect time.Time
+		iserr  bool
+	}{
+		{utc, utc, false},
+		{&utc, utc, false},
+		{int(unix), utc, false},
+		{int64(unix), utc, false},
+		{int32(unix), utc, false},
+		{uint(unix), utc, false},
+		{uint64(unix), utc, false},
+		{uint32(unix), utc, false},
+		{float64(unix), utc, false},
+		{float64(unix) + 0.5, utc.Add(500 * time.Millisecond), false},
+		{big.NewInt(unix), utc, false},
+		{big.NewRat(2*unix+1, 2), utc.Add(500 * time.Millisecond), false},
+		{big.NewFloat(float64(unix)), utc, false},
+		{complex(float64(unix), 0), utc, false},
+		{"2009-11-10T23:00:00Z", utc, false},
+		{"2009-11-10T23:00:00", local, false},
+		{"2009-11-10 23:00:00", local, false},
+		{"2009-11-10", time.Date(2009, 11, 10, 0, 0, 0, 0, time.Local), false},
+		{"10 Nov 2009", time.Date(2009, 11, 10, 0, 0, 0, 0, time.Local), false},
+		{"Tue, 10 Nov 2009 23:00:00 UTC", local, false},
+		{"Tue Nov 10 23:00:00 2009", local, false},
+		{"Tue Nov 10 23:00:00 UTC 2009", local, false},
+		{"2009-11-11T01:00:00+02:00", utc, false},
+		{"2009-11-10 23:00:00 +0000 UTC", utc, false},
+		{"2009-11-10 23:00:00 -07:00", utc.Add(7 * time.Hour), false},
+		{"1257894000", utc, false},
+		{json.Number("1257894000"), utc, false},
+		{[]byte("2009-11-10T23:00:00Z"), utc, false},
+		{stringer("2009-11-10T23:00:00Z"), utc, false},
+		{errors.New("2009-11-10T23:00:00Z"), utc, false},
+		{nil, time.Time{}, false},
+		// errors
+		{"test", time.Time{}, true},
+		{"2009-13-45", time.Time{}, true},
+		{"-1257894000", time.Time{}, true},
+		{"99999999999999999999", time.Time{}, true},
+		{uint64(1 << 63), time.Time{}, true},
+		{testing.T{}, time.Time{}, true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := cast.ToTimeE(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+			continue
+		}
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v.Equal(test.expect), IsTrue, Commentf("i = %d, input = %#v, got %v", i, test.input, v))
+
+		// Non-E test
+		v = cast.ToTime(test.input)
+		c.Assert(v.Equal(test.expect), IsTrue, errmsg)
+	}
+}
+
+func TestToTimeEOptions(t *testing.T) {
+	c := New(t)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	// Layouts without a numeric offset are read in the given location.
+	v, err := cast.ToTimeE("2009-11-10 23:00:00", tokyo)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, time.Date(2009, 11, 10, 23, 0, 0, 0, tokyo))
+
+	v, err = cast.ToTimeE("Tue, 10 Nov 2009 23:00:00 MST", tokyo)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, time.Date(2009, 11, 10, 23, 0, 0, 0, tokyo))
+
+	// Numeric offsets win over the location.
+	v, err = cast.ToTimeE("2009-11-10T23:00:00-07:00", tokyo)
+	c.Assert(err, IsNil)
+	_, offset := v.Zone()
+	c.Assert(offset, Equals, -7*60*60)
+
+	// Unix timestamps are reported in the location.
+	v, err = cast.ToTimeE(0, tokyo)
+	c.Assert(err, IsNil)
+	c.Assert(v.Location(), Equals, tokyo)
+	c.Assert(v.Unix(), Equals, int64(0))
+
+	// Custom layouts replace the defaults.
+	layout := cast.TimeFormat{Format: "02/01/2006 15h04", Type: cast.TimeFormatNoTimezone}
+	v, err = cast.ToTimeE("10/11/2009 23h00", layout, tokyo)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, time.Date(2009, 11, 10, 23, 0, 0, 0, tokyo))
+
+	_, err = cast.ToTimeE("2009-11-10", layout)
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+
+	_, err = cast.ToTimeE("2009-11-10", "not an option")
+	c.Assert(err, ErrorMatches, `unsupported option "not an option" of type string`)
+}
+
+func TestTimeFormatTypeString(t *testing.T) {
+	c := New(t)
+
+	c.Assert(cast.TimeFormatNamedTimezone.String(), Equals, "TimeFormatNamedTimezone")
+	c.Assert(cast.TimeFormatTimeOnly.String(), Equals, "TimeFormatTimeOnly")
+	c.Assert(cast.TimeFormatType(42).String(), Equals, "TimeFormatType(42)")
+}
+
+func TestToDurationE(t *testing.T) {
+	c := New(t)
+
+	var td time.Duration = 5
+	var jn json.Number
+	_ = json.Unmarshal([]byte("5"), &jn)
+
+	tests := []struct {
+		input  any
+		expect time.Duration
+		iserr  bool
+	}{
+		{time.Duration(5), td, false},
+		{int(5), td, false},
+		{int64(5), td, false},
+		{int32(5), td, false},
+		{int16(5), td, false},
+		{int8(5), td, false},
+		{uint(5), td, false},
+		{uint64(5), td, false},
+		{uint32(5), td, false},
+		{uint16(5), td, false},
+		{uint8(5), td, false},
+		{float64(5), td, false},
+		{float32(5), td, false},
+		{big.NewInt(5), td, false},
+		{jn, td, false},
+		{string("5"), td, false},
+		{string("5ns"), td, false},
+		{string("5us"), time.Microsecond * td, false},
+		{string("5µs"), time.Microsecond * td, false},
+		{string("5μs"), time.Microsecond * td, false},
+		{string("5ms"), time.Millisecond * td, false},
+		{string("5s"), time.Second * td, false},
+		{string("5m"), time.Minute * td, false},
+		{string("5h"), time.Hour * td, false},
+		{string("1.5h"), 90 * time.Minute, false},
+		{string(".5s"), 500 * time.Millisecond, false},
+		{string("2h45m30.5s"), 2*time.Hour + 45*time.Minute + 30500*time.Millisecond, false},
+		{string("-1h30m"), -90 * time.Minute, false},
+		{string("+1h-30m"), 30 * time.Minute, false},
+		{string("0"), 0, false},
+		{[]byte("5s"), 5 * time.Second, false},
+		{nil, 0, false},
+		// errors
+		{"test", 0, true},
+		{"", 0, true},
+		{"5x", 0, true},
+		{"1h30", 0, true},
+		{"1..5s", 0, true},
+		{"1.5d", 0, true},
+		{"3000000h", 0, true},
+		{float64(1e19), 0, true},
+		{testing.T{}, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %#v", i, test.input)
+
+		v, err := cast.ToDurationE(test.input)
+		if test.iserr {
+			c.Assert(err, IsNotNil, errmsg)
+			continue
+		}
+
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+
+		// Non-E test
+		v = cast.ToDuration(test.input)
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+}
+
+func TestToDurationECalendar(t *testing.T) {
+	c := New(t)
+
+	day := 24 * time.Hour
+	jan31 := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	leap := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input     string
+		reference time.Time
+		expect    time.Duration
+	}{
+		{"1d", leap, day},
+		{"1y", leap, 366 * day},
+		{"1y", jan31, 365 * day},
+		{"1M", leap, 31 * day},
+		{"1M", jan31, 31 * day}, // Feb 31 normalizes to Mar 3
+		{"-1M", leap, -31 * day},
+		{"1y-2d", leap, 364 * day},
+		{"1y2M3d4h", leap, (366+31+28+3)*day + 4*time.Hour},
+		{"-1d12h", leap, -36 * time.Hour},
+		// without a reference, the Unix epoch
+		{"1M", time.Time{}, 31 * day},
+		{"2M", time.Time{}, 59 * day},
+		{"1y", time.Time{}, 365 * day},
+		{"-1y", time.Time{}, -365 * day},
+	}
+
+	for i, test := range tests {
+		errmsg := Commentf("i = %d, input = %q", i, test.input)
+
+		v, err := cast.ToDurationE(test.input, test.reference)
+		c.Assert(err, IsNil, errmsg)
+		c.Assert(v, Equals, test.expect, errmsg)
+	}
+
+	_, err := cast.ToDurationE("test")
+	c.Assert(errors.Is(err, cast.ErrSyntax), IsTrue)
+
+	_, err = cast.ToDurationE("1d", time.UTC)
+	c.Assert(err, ErrorMatches, `unsupported option .* of type \*time.Location`)
+}